@@ -0,0 +1,57 @@
+package timetype
+
+// AMPMClock is a Clock, that is marshaled into JSON and text in the 12-hour form
+// with seconds, like "7:24:00 PM", regardless of the default layout. Otherwise it
+// behaves as Clock does, e.g. it's parsed in any of the known layouts, so both
+// "7:24:00 PM" and "19:24:00" are accepted.
+type AMPMClock struct {
+	Clock
+}
+
+// MarshalJSON marshals the clock in the 12-hour form, like "7:24:00 PM". As with
+// Clock, the zero clock is marshaled into null, if it's enabled with SetNullZeroClock,
+// and ErrPrecisionLoss is returned in the strict precision mode for fractions of the
+// second. The end of the day has no 12-hour form, ErrInvalidClock is returned for it.
+func (h AMPMClock) MarshalJSON() ([]byte, error) {
+	if nullZeroClock && h.IsZero() {
+		return []byte("null"), nil
+	}
+	s, err := h.format()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(quote(s)), nil
+}
+
+// UnmarshalJSON parses the clock as Clock does, JSON null leaves the clock unchanged
+func (h *AMPMClock) UnmarshalJSON(b []byte) error {
+	return h.Clock.UnmarshalJSON(b)
+}
+
+// MarshalText implements encoding.TextMarshaler and marshals the clock
+// in the 12-hour form, as MarshalJSON does, but without quotes
+func (h AMPMClock) MarshalText() ([]byte, error) {
+	s, err := h.format()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and parses the clock as Clock does
+func (h *AMPMClock) UnmarshalText(b []byte) error {
+	return h.Clock.UnmarshalText(b)
+}
+
+// format returns the clock in the 12-hour form, checking the precision as Clock does.
+// The end of the day would be formatted as the midnight at the start of the day,
+// so it's rejected.
+func (h AMPMClock) format() (string, error) {
+	if h.IsEndOfDay() {
+		return "", ErrInvalidClock
+	}
+	if err := h.checkPrecisionIn(TwelveHourClockSeconds); err != nil {
+		return "", err
+	}
+	return h.Format(TwelveHourClockSeconds), nil
+}
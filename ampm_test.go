@@ -0,0 +1,90 @@
+package timetype
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAMPMClock_JSON(t *testing.T) {
+	tbl := []struct {
+		arg      AMPMClock
+		expected string
+	}{
+		{arg: AMPMClock{NewUTCClock(19, 24, 0, 0)}, expected: `"7:24:00 PM"`},
+		{arg: AMPMClock{NewUTCClock(11, 5, 0, 0)}, expected: `"11:05:00 AM"`},
+		{arg: AMPMClock{NewUTCClock(0, 0, 5, 0)}, expected: `"12:00:05 AM"`},
+		{arg: AMPMClock{NewUTCClock(12, 30, 0, 0)}, expected: `"12:30:00 PM"`},
+	}
+	for i, tt := range tbl {
+		b, err := json.Marshal(tt.arg)
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, string(b), "case #%d", i)
+
+		var c AMPMClock
+		require.NoError(t, json.Unmarshal(b, &c), "case #%d", i)
+		assert.Equal(t, tt.arg, c, "case #%d", i)
+
+		b, err = tt.arg.MarshalText()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected[1:len(tt.expected)-1], string(b), "case #%d", i)
+
+		c = AMPMClock{}
+		require.NoError(t, c.UnmarshalText(b), "case #%d", i)
+		assert.Equal(t, tt.arg, c, "case #%d", i)
+	}
+
+	var c AMPMClock
+	require.NoError(t, json.Unmarshal([]byte(`"19:24:00"`), &c))
+	assert.Equal(t, AMPMClock{NewUTCClock(19, 24, 0, 0)}, c)
+	require.NoError(t, json.Unmarshal([]byte(`null`), &c))
+	assert.Equal(t, AMPMClock{NewUTCClock(19, 24, 0, 0)}, c)
+	assert.Error(t, json.Unmarshal([]byte(`"13:00 PM"`), &c))
+
+	var s struct {
+		Open  AMPMClock `json:"open"`
+		Close Clock     `json:"close"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"open":"9:00:00 AM","close":"7:00 PM"}`), &s))
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"open":"9:00:00 AM","close":"19:00:00.000000"}`, string(b))
+}
+
+func TestAMPMClock_Checks(t *testing.T) {
+	defer SetNullZeroClock(nullZeroClock)
+	defer SetStrictClockPrecision(strictClockPrecision)
+
+	eod := AMPMClock{EndOfDay(time.UTC)}
+	_, err := json.Marshal(eod)
+	assert.True(t, errors.Is(err, ErrInvalidClock), err)
+	_, err = eod.MarshalText()
+	assert.Equal(t, ErrInvalidClock, err)
+
+	SetNullZeroClock(true)
+	b, err := json.Marshal(AMPMClock{})
+	require.NoError(t, err)
+	assert.Equal(t, `null`, string(b))
+	SetNullZeroClock(false)
+	b, err = json.Marshal(AMPMClock{})
+	require.NoError(t, err)
+	assert.Equal(t, `"12:00:00 AM"`, string(b))
+
+	withFraction := AMPMClock{NewUTCClock(19, 24, 0, 500000000)}
+	b, err = json.Marshal(withFraction)
+	require.NoError(t, err)
+	assert.Equal(t, `"7:24:00 PM"`, string(b), "truncated in the lenient mode")
+
+	SetStrictClockPrecision(true)
+	_, err = json.Marshal(withFraction)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss), err)
+	_, err = withFraction.MarshalText()
+	assert.Equal(t, ErrPrecisionLoss, err)
+	b, err = json.Marshal(AMPMClock{NewUTCClock(19, 24, 5, 0)})
+	require.NoError(t, err)
+	assert.Equal(t, `"7:24:05 PM"`, string(b))
+}
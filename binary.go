@@ -0,0 +1,110 @@
+package timetype
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+)
+
+// Formats of the Clock binary representation, stored in its first byte,
+// they tell how the location of the clock is restored
+const (
+	clockBinaryFixedZone byte = iota + 1 // fixed zone with the stored offset
+	clockBinaryUTC                       // UTC location
+	clockBinaryLocal                     // local location of the decoding host
+	clockBinaryZero                      // zero Clock, the rest of the bytes are zeros
+)
+
+// clockBinaryLen is the length of the Clock binary representation:
+// format byte, int64 nanoseconds since midnight, 24h for the end of the day,
+// and int32 zone offset in seconds
+const clockBinaryLen = 1 + 8 + 4
+
+// MarshalBinary implements encoding.BinaryMarshaler. Only the time of the day
+// and the zone offset of the clock are encoded, the name of the location is lost,
+// except for UTC and the local location, which are marked as such.
+func (h Clock) MarshalBinary() ([]byte, error) {
+	b := make([]byte, clockBinaryLen)
+	if h.IsZero() {
+		b[0] = clockBinaryZero
+		return b, nil
+	}
+
+	_, offset := time.Time(h).Zone()
+	switch time.Time(h).Location() {
+	case time.UTC:
+		b[0] = clockBinaryUTC
+	case time.Local:
+		b[0] = clockBinaryLocal
+	default:
+		b[0] = clockBinaryFixedZone
+	}
+	binary.BigEndian.PutUint64(b[1:], uint64(h.sinceMidnight()))
+	binary.BigEndian.PutUint32(b[9:], uint32(int32(offset)))
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The clock is restored at the
+// zero date in the location marked in the data, UTC, the local location of the host, or
+// the fixed zone with the stored offset otherwise. The zero Clock is restored as zero.
+func (h *Clock) UnmarshalBinary(b []byte) error {
+	if len(b) != clockBinaryLen {
+		return ErrInvalidClock
+	}
+	if b[0] == clockBinaryZero {
+		if !bytes.Equal(b[1:], make([]byte, clockBinaryLen-1)) {
+			return ErrInvalidClock
+		}
+		*h = Clock{}
+		return nil
+	}
+
+	off := time.Duration(binary.BigEndian.Uint64(b[1:]))
+	if off < 0 || off > day {
+		return ErrInvalidClock
+	}
+	offset := int(int32(binary.BigEndian.Uint32(b[9:])))
+
+	var loc *time.Location
+	switch b[0] {
+	case clockBinaryFixedZone:
+		loc = time.FixedZone("", offset)
+	case clockBinaryUTC:
+		loc = time.UTC
+	case clockBinaryLocal:
+		loc = time.Local
+	default:
+		return ErrInvalidClock
+	}
+	*h = clockAt(off, loc)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder and encodes the clock as MarshalBinary does,
+// so only the time of the day and the zone offset are transferred
+func (h Clock) GobEncode() ([]byte, error) {
+	return h.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder and decodes the clock as UnmarshalBinary does
+func (h *Clock) GobDecode(b []byte) error {
+	return h.UnmarshalBinary(b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and encodes
+// the duration as 8 bytes of big-endian int64 nanoseconds
+func (d Duration) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(d))
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and decodes
+// the duration from 8 bytes of big-endian int64 nanoseconds
+func (d *Duration) UnmarshalBinary(b []byte) error {
+	if len(b) != 8 {
+		return ErrInvalidDuration
+	}
+	*d = Duration(int64(binary.BigEndian.Uint64(b)))
+	return nil
+}
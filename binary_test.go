@@ -0,0 +1,138 @@
+package timetype
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClock_MarshalBinary(t *testing.T) {
+	plus2 := time.FixedZone("", 2*60*60)
+	minus5 := time.FixedZone("", -5*60*60)
+
+	tbl := []Clock{
+		NewUTCClock(0, 0, 0, 0),
+		NewUTCClock(19, 24, 0, 0),
+		NewUTCClock(23, 59, 59, 999999999),
+		NewClock(19, 24, 5, 123456789, plus2),
+		NewClock(7, 0, 0, 0, minus5),
+		EndOfDay(time.UTC),
+		EndOfDay(plus2),
+		NewClock(19, 24, 0, 0, time.Local),
+		EndOfDay(time.Local),
+		NewClock(19, 24, 0, 0, time.FixedZone("", 0)),
+		Clock{},
+	}
+	for i, tt := range tbl {
+		b, err := tt.MarshalBinary()
+		require.NoError(t, err, "case #%d", i)
+		assert.Len(t, b, 13, "case #%d", i)
+
+		var c Clock
+		require.NoError(t, c.UnmarshalBinary(b), "case #%d", i)
+		assert.Equal(t, tt, c, "case #%d", i)
+	}
+
+	b, err := NewClock(19, 24, 5, 0, plus2).MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 0, 0, 0x3f, 0x86, 0x0e, 0x6a, 0x12, 0x00, 0, 0, 0x1c, 0x20}, b)
+
+	b, err = NewUTCClock(19, 24, 5, 0).MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2, 0, 0, 0x3f, 0x86, 0x0e, 0x6a, 0x12, 0x00, 0, 0, 0, 0}, b)
+
+	b, err = Clock{}.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, b)
+
+	// the location is restored from the format byte, regardless of the local offset
+	b, err = NewClock(19, 24, 5, 0, time.Local).MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, byte(3), b[0])
+	var c Clock
+	require.NoError(t, c.UnmarshalBinary(b))
+	assert.Equal(t, time.Local, c.Location())
+	b[0] = 1
+	require.NoError(t, c.UnmarshalBinary(b))
+	assert.NotEqual(t, time.Local, c.Location())
+}
+
+func TestClock_UnmarshalBinary(t *testing.T) {
+	tbl := [][]byte{
+		nil,
+		{},
+		{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{5, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{4, 0, 0, 0, 0, 0, 0, 0, 0x01, 0, 0, 0, 0},
+		{1, 0, 0, 0x4e, 0x94, 0x91, 0x4f, 0x00, 0x01, 0, 0, 0, 0}, // 24h + 1ns
+		{1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0},
+	}
+	for i, tt := range tbl {
+		var c Clock
+		assert.Equal(t, ErrInvalidClock, c.UnmarshalBinary(tt), "case #%d", i)
+		assert.Equal(t, Clock{}, c, "case #%d", i)
+	}
+}
+
+func TestClock_Gob(t *testing.T) {
+	type s struct {
+		C Clock
+	}
+	tbl := []Clock{
+		NewUTCClock(0, 0, 0, 0),
+		NewUTCClock(19, 24, 5, 123456789),
+		NewClock(7, 0, 0, 0, time.FixedZone("", -5*60*60)),
+		NewClock(7, 0, 0, 0, time.Local),
+		Clock{},
+	}
+	for i, tt := range tbl {
+		buf := &bytes.Buffer{}
+		require.NoError(t, gob.NewEncoder(buf).Encode(s{C: tt}), "case #%d", i)
+		var res s
+		require.NoError(t, gob.NewDecoder(buf).Decode(&res), "case #%d", i)
+		assert.Equal(t, tt, res.C, "case #%d", i)
+
+		b, err := tt.GobEncode()
+		require.NoError(t, err, "case #%d", i)
+		tb, err := time.Time(tt).GobEncode()
+		require.NoError(t, err, "case #%d", i)
+		assert.Less(t, len(b), len(tb), "case #%d", i)
+	}
+
+	var c Clock
+	assert.Equal(t, ErrInvalidClock, c.GobDecode([]byte{1, 2, 3}))
+}
+
+func TestDuration_MarshalBinary(t *testing.T) {
+	tbl := []struct {
+		arg      Duration
+		expected []byte
+	}{
+		{arg: 0, expected: []byte{0, 0, 0, 0, 0, 0, 0, 0}},
+		{arg: Duration(time.Second), expected: []byte{0, 0, 0, 0, 0x3b, 0x9a, 0xca, 0x00}},
+		{arg: Duration(-time.Nanosecond), expected: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{arg: Duration(-(time.Hour + 5*time.Minute)), expected: []byte{0xff, 0xff, 0xfc, 0x73, 0xf5, 0xe2, 0xa8, 0x00}},
+	}
+	for i, tt := range tbl {
+		b, err := tt.arg.MarshalBinary()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, b, "case #%d", i)
+
+		var d Duration
+		require.NoError(t, d.UnmarshalBinary(b), "case #%d", i)
+		assert.Equal(t, tt.arg, d, "case #%d", i)
+	}
+}
+
+func TestDuration_UnmarshalBinary(t *testing.T) {
+	for i, tt := range [][]byte{nil, {}, {0, 0, 0, 0, 0, 0, 0}, {0, 0, 0, 0, 0, 0, 0, 0, 0}} {
+		d := Duration(time.Second)
+		assert.Equal(t, ErrInvalidDuration, d.UnmarshalBinary(tt), "case #%d", i)
+		assert.Equal(t, Duration(time.Second), d, "case #%d", i)
+	}
+}
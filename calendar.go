@@ -0,0 +1,186 @@
+package timetype
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCalendarPeriod is returned when the calendar period can't be parsed
+var ErrInvalidCalendarPeriod = errors.New("timetype: invalid calendar period")
+
+// CalendarPeriod is a calendar-aware span of years, months and days, like "P1Y2M3D"
+// in ISO 8601. Unlike Duration, its length depends on the time it's added to, e.g.
+// a month from January 31 is March 3 (or 2 in leap years), as in time.Time.AddDate.
+type CalendarPeriod struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// ParseCalendarPeriod parses the calendar period in ISO 8601 format with the date
+// part only, like "P1Y2M3D" or "P2W", where weeks are 7 days. The whole period may be
+// negated with the leading "-", as in "-P1M", and any component may be negative,
+// as in "P1Y-2M".
+func ParseCalendarPeriod(s string) (CalendarPeriod, error) {
+	sign := 1
+	if strings.HasPrefix(s, "-") {
+		sign, s = -1, s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) == 1 {
+		return CalendarPeriod{}, ErrInvalidCalendarPeriod
+	}
+	s = s[1:]
+
+	var p CalendarPeriod
+	last := -1
+	for s != "" {
+		i := 0
+		if s[0] == '-' || s[0] == '+' {
+			i++
+		}
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if i == start || i == len(s) {
+			return CalendarPeriod{}, ErrInvalidCalendarPeriod
+		}
+		n, err := strconv.ParseInt(s[:i], 10, 32)
+		if err != nil {
+			return CalendarPeriod{}, ErrInvalidCalendarPeriod
+		}
+		idx := strings.IndexByte("YMWD", s[i])
+		if idx <= last {
+			return CalendarPeriod{}, ErrInvalidCalendarPeriod
+		}
+		last = idx
+		switch s[i] {
+		case 'Y':
+			p.Years = sign * int(n)
+		case 'M':
+			p.Months = sign * int(n)
+		case 'W':
+			p.Days += sign * int(n) * 7
+		case 'D':
+			p.Days += sign * int(n)
+		}
+		s = s[i+1:]
+	}
+	return p, nil
+}
+
+// String returns the calendar period in ISO 8601 format, like "P1Y2M3D", zero
+// components are omitted and the zero period is "P0D". If all nonzero components
+// are negative, the period is negated as a whole, like "-P1M".
+func (p CalendarPeriod) String() string {
+	if p == (CalendarPeriod{}) {
+		return "P0D"
+	}
+
+	b := make([]byte, 0, 16)
+	if p.Years <= 0 && p.Months <= 0 && p.Days <= 0 {
+		b = append(b, '-')
+		p = p.Negate()
+	}
+	b = append(b, 'P')
+	if p.Years != 0 {
+		b = append(strconv.AppendInt(b, int64(p.Years), 10), 'Y')
+	}
+	if p.Months != 0 {
+		b = append(strconv.AppendInt(b, int64(p.Months), 10), 'M')
+	}
+	if p.Days != 0 {
+		b = append(strconv.AppendInt(b, int64(p.Days), 10), 'D')
+	}
+	return string(b)
+}
+
+// IsZero reports whether the period has no years, months and days
+func (p CalendarPeriod) IsZero() bool {
+	return p == CalendarPeriod{}
+}
+
+// Negate returns the period with all components negated
+func (p CalendarPeriod) Negate() CalendarPeriod {
+	return CalendarPeriod{Years: -p.Years, Months: -p.Months, Days: -p.Days}
+}
+
+// Normalize returns the period with months carried into years, so that months
+// are within (-12, 12) and have the same sign as years, e.g. "P1Y14M" becomes
+// "P2Y2M" and "P1Y-2M" becomes "P10M". Days are left as is, as months have no
+// fixed amount of days.
+func (p CalendarPeriod) Normalize() CalendarPeriod {
+	total := p.Years*12 + p.Months
+	return CalendarPeriod{Years: total / 12, Months: total % 12, Days: p.Days}
+}
+
+// AddTo returns the time shifted by the period, as time.Time.AddDate does, e.g.
+// "P1M" added to January 31 is normalized to March 3 (or 2 in leap years)
+func (p CalendarPeriod) AddTo(t time.Time) time.Time {
+	return t.AddDate(p.Years, p.Months, p.Days)
+}
+
+// MarshalJSON marshals the calendar period into the string in ISO 8601 format
+func (p CalendarPeriod) MarshalJSON() ([]byte, error) {
+	res, err := json.Marshal(p.String())
+	return res, wrapExternalErr("marshal", "calendar period", err)
+}
+
+// UnmarshalJSON parses the calendar period from the string in ISO 8601 format
+func (p *CalendarPeriod) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return wrapExternalErr("unmarshal", "calendar period", err)
+	}
+	val, ok := v.(string)
+	if !ok {
+		return ErrInvalidCalendarPeriod
+	}
+	res, err := ParseCalendarPeriod(val)
+	if err != nil {
+		return err
+	}
+	*p = res
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and marshals
+// the calendar period in the same format as String does
+func (p CalendarPeriod) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and parses
+// the calendar period as ParseCalendarPeriod does
+func (p *CalendarPeriod) UnmarshalText(b []byte) error {
+	res, err := ParseCalendarPeriod(string(b))
+	if err != nil {
+		return err
+	}
+	*p = res
+	return nil
+}
+
+// Scan the given SQL value as CalendarPeriod, NULL is scanned as the zero period
+func (p *CalendarPeriod) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*p = CalendarPeriod{}
+		return nil
+	case string:
+		return p.UnmarshalText([]byte(v))
+	case []byte:
+		return p.UnmarshalText(v)
+	default:
+		return ErrInvalidCalendarPeriod
+	}
+}
+
+// Value returns the SQL value of the given CalendarPeriod in ISO 8601 format
+func (p CalendarPeriod) Value() (driver.Value, error) {
+	return p.String(), nil
+}
@@ -0,0 +1,166 @@
+package timetype
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCalendarPeriod(t *testing.T) {
+	tbl := []struct {
+		arg      string
+		expected CalendarPeriod
+		str      string
+	}{
+		{arg: "P1Y2M3D", expected: CalendarPeriod{Years: 1, Months: 2, Days: 3}, str: "P1Y2M3D"},
+		{arg: "P1Y", expected: CalendarPeriod{Years: 1}, str: "P1Y"},
+		{arg: "P14M", expected: CalendarPeriod{Months: 14}, str: "P14M"},
+		{arg: "P2W", expected: CalendarPeriod{Days: 14}, str: "P14D"},
+		{arg: "P1W3D", expected: CalendarPeriod{Days: 10}, str: "P10D"},
+		{arg: "P0D", expected: CalendarPeriod{}, str: "P0D"},
+		{arg: "P0Y0M", expected: CalendarPeriod{}, str: "P0D"},
+		{arg: "-P1Y2M", expected: CalendarPeriod{Years: -1, Months: -2}, str: "-P1Y2M"},
+		{arg: "P-1M", expected: CalendarPeriod{Months: -1}, str: "-P1M"},
+		{arg: "P1Y-2M", expected: CalendarPeriod{Years: 1, Months: -2}, str: "P1Y-2M"},
+		{arg: "-P1Y-2M", expected: CalendarPeriod{Years: -1, Months: 2}, str: "P-1Y2M"},
+		{arg: "P+3D", expected: CalendarPeriod{Days: 3}, str: "P3D"},
+	}
+	for i, tt := range tbl {
+		p, err := ParseCalendarPeriod(tt.arg)
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, p, "case #%d", i)
+		assert.Equal(t, tt.str, p.String(), "case #%d", i)
+
+		p, err = ParseCalendarPeriod(p.String())
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, p, "case #%d", i)
+	}
+
+	for i, tt := range []string{
+		"", "P", "-P", "1Y", "p1Y", "P1", "PY", "P1Y2", "P1M1Y", "P1D1W", "P1Y1Y",
+		"P1H", "PT1H", "P1DT1H", "P1.5Y", "P--1Y", "P99999999999Y", " P1Y",
+	} {
+		_, err := ParseCalendarPeriod(tt)
+		assert.Equal(t, ErrInvalidCalendarPeriod, err, "case #%d", i)
+	}
+}
+
+func TestCalendarPeriod_Normalize(t *testing.T) {
+	tbl := []struct {
+		arg, expected CalendarPeriod
+	}{
+		{arg: CalendarPeriod{Years: 1, Months: 14, Days: 40}, expected: CalendarPeriod{Years: 2, Months: 2, Days: 40}},
+		{arg: CalendarPeriod{Years: 1, Months: -2}, expected: CalendarPeriod{Months: 10}},
+		{arg: CalendarPeriod{Years: -1, Months: 2}, expected: CalendarPeriod{Months: -10}},
+		{arg: CalendarPeriod{Months: -25}, expected: CalendarPeriod{Years: -2, Months: -1}},
+		{arg: CalendarPeriod{Months: 12}, expected: CalendarPeriod{Years: 1}},
+		{arg: CalendarPeriod{}, expected: CalendarPeriod{}},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.Normalize(), "case #%d", i)
+	}
+}
+
+func TestCalendarPeriod_AddTo(t *testing.T) {
+	tbl := []struct {
+		period   string
+		arg      time.Time
+		expected time.Time
+	}{
+		{
+			period:   "P1Y2M3D",
+			arg:      time.Date(2023, time.May, 1, 19, 24, 0, 0, time.UTC),
+			expected: time.Date(2024, time.July, 4, 19, 24, 0, 0, time.UTC),
+		},
+		{
+			period:   "P1M",
+			arg:      time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, time.March, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			period:   "P1M",
+			arg:      time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			period:   "-P1W",
+			arg:      time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, time.April, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			period:   "P0D",
+			arg:      time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for i, tt := range tbl {
+		p, err := ParseCalendarPeriod(tt.period)
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, p.AddTo(tt.arg), "case #%d", i)
+	}
+	assert.True(t, CalendarPeriod{}.IsZero())
+	assert.False(t, CalendarPeriod{Days: 1}.IsZero())
+}
+
+func TestCalendarPeriod_JSON(t *testing.T) {
+	type s struct {
+		Period CalendarPeriod `json:"period"`
+	}
+	var res s
+	require.NoError(t, res.Period.UnmarshalJSON([]byte(`"P1Y2M3D"`)))
+	assert.Equal(t, CalendarPeriod{Years: 1, Months: 2, Days: 3}, res.Period)
+
+	b, err := res.Period.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"P1Y2M3D"`, string(b))
+
+	err = res.Period.UnmarshalJSON([]byte(`P1Y`))
+	assert.IsType(t, &errExternal{}, err)
+	assert.Equal(t, ErrInvalidCalendarPeriod, res.Period.UnmarshalJSON([]byte(`12`)))
+	assert.Equal(t, ErrInvalidCalendarPeriod, res.Period.UnmarshalJSON([]byte(`"PT1H"`)))
+	assert.Equal(t, CalendarPeriod{Years: 1, Months: 2, Days: 3}, res.Period)
+
+	b, err = CalendarPeriod{Months: -1}.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "-P1M", string(b))
+	require.NoError(t, res.Period.UnmarshalText([]byte("P2W")))
+	assert.Equal(t, CalendarPeriod{Days: 14}, res.Period)
+	assert.Equal(t, ErrInvalidCalendarPeriod, res.Period.UnmarshalText([]byte("2W")))
+}
+
+func TestCalendarPeriod_Scan(t *testing.T) {
+	tbl := []struct {
+		arg      interface{}
+		expected CalendarPeriod
+		err      error
+	}{
+		{arg: nil, expected: CalendarPeriod{}},
+		{arg: "P1Y2M3D", expected: CalendarPeriod{Years: 1, Months: 2, Days: 3}},
+		{arg: []byte("P1M"), expected: CalendarPeriod{Months: 1}},
+		{arg: "1 month", err: ErrInvalidCalendarPeriod},
+		{arg: 12, err: ErrInvalidCalendarPeriod},
+	}
+	for i, tt := range tbl {
+		var p CalendarPeriod
+		err := p.Scan(tt.arg)
+		if tt.err != nil {
+			assert.Equal(t, tt.err, err, "case #%d", i)
+			continue
+		}
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, p, "case #%d", i)
+	}
+}
+
+func TestCalendarPeriod_Value(t *testing.T) {
+	v, err := CalendarPeriod{Years: 1, Months: 2, Days: 3}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, driver.Value("P1Y2M3D"), v)
+
+	v, err = CalendarPeriod{}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, driver.Value("P0D"), v)
+}
@@ -0,0 +1,102 @@
+package timetype
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ISO8601Date is the template to parse and format dates
+const ISO8601Date = "2006-01-02"
+
+// Date is a wrapper for time.Time to allow parsing datetime stamp with date only in
+// ISO 8601 format, like "2006-01-02". The time of the date is always midnight in UTC.
+type Date time.Time
+
+// NewDate returns the Date with the given year, month and day, values out of their
+// ranges are normalized, as in time.Date, e.g. October 32 becomes November 1
+func NewDate(year int, month time.Month, day int) Date {
+	return Date(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// dateOf returns the date of the given time.Time in its location
+func dateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return NewDate(year, month, day)
+}
+
+// String implements fmt.Stringer to print and log Date properly
+func (dt Date) String() string {
+	return time.Time(dt).Format(ISO8601Date)
+}
+
+// GoString implements fmt.GoStringer to use Date in %#v formats
+func (dt Date) GoString() string {
+	year, month, day := time.Time(dt).Date()
+	return fmt.Sprintf("timetype.NewDate(%d, %d, %d)", year, month, day)
+}
+
+// MarshalJSON marshals date into ISO 8601 representation
+func (dt Date) MarshalJSON() ([]byte, error) {
+	res, err := json.Marshal(time.Time(dt).Format(ISO8601Date))
+	return res, wrapExternalErr("marshal", "date", err)
+}
+
+// UnmarshalJSON parses date from ISO 8601 representation
+func (dt *Date) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return wrapExternalErr("unmarshal", "date", err)
+	}
+	val, ok := v.(string)
+	if !ok {
+		return ErrInvalidDate
+	}
+	d, err := parseDate(val)
+	if err != nil {
+		return err
+	}
+	*dt = d
+	return nil
+}
+
+// parseDate parses the date in ISO 8601 layout
+func parseDate(val string) (Date, error) {
+	t, err := TryParseTime(val, ISO8601Date)
+	if err != nil {
+		return Date{}, err
+	}
+	return dateOf(t), nil
+}
+
+// Scan the given SQL value as Date
+func (dt *Date) Scan(src interface{}) (err error) {
+	switch v := src.(type) {
+	case nil:
+		*dt = Date{}
+	case time.Time:
+		*dt = dateOf(v)
+	case string:
+		d, err := parseDate(v)
+		if err != nil {
+			return err
+		}
+		*dt = d
+	case []byte:
+		d, err := parseDate(string(v))
+		if err != nil {
+			return err
+		}
+		*dt = d
+	default:
+		return ErrInvalidDate
+	}
+
+	return err
+}
+
+// Value returns the SQL value of the given Date
+func (dt Date) Value() (driver.Value, error) {
+	return time.Time(dt).Format(ISO8601Date), nil
+}
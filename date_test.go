@@ -0,0 +1,128 @@
+package timetype
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDate_GoString(t *testing.T) {
+	s := Date(time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC)).GoString()
+	assert.Equal(t, "timetype.NewDate(2023, 5, 1)", s)
+}
+
+func TestDate_String(t *testing.T) {
+	s := Date(time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC)).String()
+	assert.Equal(t, "2023-05-01", s)
+}
+
+func TestNewDate(t *testing.T) {
+	assert.Equal(t, Date(time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC)), NewDate(2023, time.May, 1))
+	assert.Equal(t, Date(time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC)), NewDate(2023, time.October, 32))
+}
+
+func TestDate_UnmarshalJSON(t *testing.T) {
+	var d Date
+	err := d.UnmarshalJSON([]byte("\"2023-05-01\""))
+	require.NoError(t, err)
+	assert.Equal(t, NewDate(2023, time.May, 1), d)
+
+	// errors
+	err = d.UnmarshalJSON([]byte("2023-05-01")) // date should be presented as string
+	require.Error(t, err)
+	assert.IsType(t, &errExternal{}, err, "date should be escaped in quotes")
+
+	err = d.UnmarshalJSON([]byte("20230501")) // invalid date format
+	assert.EqualError(t, err, "timetype: invalid date", "date should be in format \"2006-01-02\"")
+	assert.Equal(t, ErrInvalidDate, err)
+
+	err = d.UnmarshalJSON([]byte("\"2023-13-01\""))
+	require.Error(t, err)
+	assert.IsType(t, &UnknownFormatError{}, err, "month out of range")
+}
+
+func TestDate_MarshalJSON(t *testing.T) {
+	bytes, err := NewDate(2023, time.May, 1).MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`"2023-05-01"`), bytes)
+}
+
+func TestDate_Scan(t *testing.T) {
+	tbl := []struct {
+		arg      interface{}
+		expected Date
+		err      string
+	}{
+		{
+			arg:      nil,
+			expected: Date(time.Time{}),
+		},
+		{
+			arg:      time.Date(2023, time.May, 1, 19, 24, 0, 0, time.UTC),
+			expected: NewDate(2023, time.May, 1),
+		},
+		{
+			arg:      time.Date(2023, time.May, 1, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60)),
+			expected: NewDate(2023, time.May, 1),
+		},
+		{
+			arg:      `2023-05-01`,
+			expected: NewDate(2023, time.May, 1),
+		},
+		{
+			arg:      []byte(`2020-02-29`),
+			expected: NewDate(2020, time.February, 29),
+		},
+		{
+			arg:      2567,
+			expected: Date{},
+			err:      "timetype: invalid date",
+		},
+		{
+			arg:      "abacaba",
+			expected: Date{},
+			err:      "timetype: failed to parse \"abacaba\" in layouts: [\"2006-01-02\"]",
+		},
+		{
+			arg:      []byte("2021-02-29"),
+			expected: Date{},
+			err:      "timetype: failed to parse \"2021-02-29\" in layouts: [\"2006-01-02\"]",
+		},
+	}
+
+	for i, tt := range tbl {
+		d := Date{}
+		err := d.Scan(tt.arg)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, "case #%d", i)
+		} else {
+			assert.NoError(t, err, "case #%d", i)
+		}
+		assert.Equal(t, tt.expected, d, "case #%d", i)
+	}
+}
+
+func TestDate_Value(t *testing.T) {
+	tbl := []struct {
+		arg      Date
+		expected driver.Value
+	}{
+		{
+			arg:      NewDate(2023, time.May, 1),
+			expected: driver.Value(`2023-05-01`),
+		},
+		{
+			arg:      NewDate(1999, time.December, 31),
+			expected: driver.Value(`1999-12-31`),
+		},
+	}
+
+	for i, tt := range tbl {
+		actual, err := tt.arg.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, actual, "case #%d", i)
+	}
+}
@@ -0,0 +1,40 @@
+package timetype
+
+// ClockValue is the clock as flag.Value, e.g. for "-cutoff 17:30". Clock itself isn't
+// one, as its String is meant for logs, like "17:30:00 UTC", and can't be parsed back,
+// so convert the pointer to the clock instead:
+//
+//	flag.Var((*timetype.ClockValue)(&cutoff), "cutoff", "the cutoff clock")
+type ClockValue Clock
+
+// Set implements flag.Value and parses the clock in any of the known layouts,
+// as ParseClock does
+func (h *ClockValue) Set(s string) error {
+	return (*Clock)(h).UnmarshalText([]byte(s))
+}
+
+// String implements flag.Value and returns the clock in the ISO8601Clock layout,
+// like "17:30:00", as ShortString does, so Set accepts it back. The nil value
+// results in the empty string.
+func (h *ClockValue) String() string {
+	if h == nil {
+		return ""
+	}
+	return Clock(*h).ShortString()
+}
+
+// Type returns the name of the flag value type, as pflag.Value requires
+func (h *ClockValue) Type() string {
+	return "clock"
+}
+
+// Set implements flag.Value and parses the duration as UnmarshalText does, so the
+// duration can be used with flag.Var, e.g. "-timeout 1h5m"
+func (d *Duration) Set(s string) error {
+	return d.UnmarshalText([]byte(s))
+}
+
+// Type returns the name of the flag value type, as pflag.Value requires
+func (d *Duration) Type() string {
+	return "duration"
+}
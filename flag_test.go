@@ -0,0 +1,54 @@
+package timetype
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagValue(t *testing.T) {
+	var (
+		_ flag.Value = (*ClockValue)(nil)
+		_ flag.Value = (*Duration)(nil)
+	)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cutoff := NewUTCClock(9, 0, 0, 0)
+	timeout := Duration(time.Second)
+	fs.Var((*ClockValue)(&cutoff), "cutoff", "")
+	fs.Var(&timeout, "timeout", "")
+
+	require.NoError(t, fs.Parse([]string{"-cutoff", "17:30", "-timeout", "1h5m"}))
+	assert.Equal(t, NewUTCClock(17, 30, 0, 0), cutoff)
+	assert.Equal(t, Duration(time.Hour+5*time.Minute), timeout)
+	assert.Equal(t, "17:30:00", fs.Lookup("cutoff").Value.String())
+	assert.Equal(t, "09:00:00", fs.Lookup("cutoff").DefValue)
+	assert.Equal(t, "1h5m0s", fs.Lookup("timeout").Value.String())
+
+	require.NoError(t, fs.Parse([]string{"-timeout", "2d"}))
+	assert.Equal(t, Duration(48*time.Hour), timeout)
+
+	require.Error(t, fs.Parse([]string{"-cutoff", "25:00"}))
+	assert.Equal(t, NewUTCClock(17, 30, 0, 0), cutoff)
+	require.Error(t, fs.Parse([]string{"-timeout", "soon"}))
+	assert.Equal(t, Duration(48*time.Hour), timeout)
+
+	assert.Equal(t, "clock", (*ClockValue)(&cutoff).Type())
+	assert.Equal(t, "", (*ClockValue)(nil).String())
+
+	// the default value is printed in the form, that Set accepts back
+	var out strings.Builder
+	fs.SetOutput(&out)
+	fs.PrintDefaults()
+	assert.Contains(t, out.String(), `(default 09:00:00)`)
+	var parsed ClockValue
+	require.NoError(t, parsed.Set(fs.Lookup("cutoff").DefValue))
+	assert.Equal(t, NewUTCClock(9, 0, 0, 0), Clock(parsed))
+	assert.Equal(t, "duration", timeout.Type())
+}
@@ -0,0 +1,111 @@
+package timetype
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func FuzzClockRoundTrip(f *testing.F) {
+	for _, seed := range []struct {
+		off           time.Duration
+		offsetMinutes int16
+	}{
+		{off: 0},
+		{off: 19*time.Hour + 24*time.Minute},
+		{off: 19*time.Hour + 24*time.Minute + 5*time.Second + 123456789, offsetMinutes: 120},
+		{off: 7 * time.Hour, offsetMinutes: -300},
+		{off: day - time.Nanosecond, offsetMinutes: 330},
+		{off: 2*time.Hour + 21*time.Minute + 55*time.Second, offsetMinutes: -840},
+	} {
+		f.Add(int64(seed.off), seed.offsetMinutes)
+	}
+
+	f.Fuzz(func(t *testing.T, off int64, offsetMinutes int16) {
+		// layouts keep the zone offset with minutes precision, up to ±14h
+		loc := time.FixedZone("", int(offsetMinutes)%(14*60+1)*60)
+		c := ClockFromDuration(time.Duration(off), loc)
+		micro := c.Truncate(time.Microsecond)
+
+		b, err := c.MarshalJSON()
+		require.NoError(t, err)
+		var fromJSON Clock
+		require.NoError(t, fromJSON.UnmarshalJSON(b))
+		assert.Equal(t, micro.Normalize(), fromJSON, "json %s", b)
+
+		b, err = c.MarshalText()
+		require.NoError(t, err)
+		var fromText Clock
+		require.NoError(t, fromText.UnmarshalText(b))
+		assert.Equal(t, micro.Normalize(), fromText, "text %s", b)
+
+		fromNano, err := ParseClock(c.Format(ISO8601ClockNano))
+		require.NoError(t, err)
+		assert.Equal(t, c.Normalize(), fromNano)
+
+		v, err := c.ValueWithZone()
+		require.NoError(t, err)
+		var fromValue Clock
+		require.NoError(t, fromValue.Scan(v))
+		assert.True(t, micro.Equal(fromValue), "value %v", v)
+		assert.Equal(t, micro.Normalize(), fromValue.Normalize(), "value %v", v)
+
+		b, err = c.MarshalBinary()
+		require.NoError(t, err)
+		var fromBinary Clock
+		require.NoError(t, fromBinary.UnmarshalBinary(b))
+		assert.True(t, c.Equal(fromBinary))
+		assert.Equal(t, c.Normalize(), fromBinary.Normalize())
+	})
+}
+
+func FuzzDurationRoundTrip(f *testing.F) {
+	for _, seed := range []time.Duration{
+		0, 1, -1, time.Second, -time.Millisecond, time.Hour + 5*time.Minute + 3*time.Second,
+		168 * time.Hour, 26*time.Hour + 59*time.Second, 1500 * time.Millisecond,
+		math.MaxInt64, math.MinInt64,
+	} {
+		f.Add(int64(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, n int64) {
+		d := Duration(n)
+
+		b, err := d.MarshalJSON()
+		require.NoError(t, err)
+		var fromJSON Duration
+		require.NoError(t, fromJSON.UnmarshalJSON(b))
+		assert.Equal(t, d, fromJSON, "json %s", b)
+
+		b, err = d.MarshalText()
+		require.NoError(t, err)
+		var fromText Duration
+		require.NoError(t, fromText.UnmarshalText(b))
+		assert.Equal(t, d, fromText, "text %s", b)
+
+		for _, s := range []string{d.ISO8601(), d.ISO8601WithDays()} {
+			fromISO, err := ParseDuration(s)
+			require.NoError(t, err, s)
+			assert.Equal(t, d, fromISO, s)
+		}
+
+		fromHMS, err := ParseHMS(d.HMS())
+		require.NoError(t, err, d.HMS())
+		assert.Equal(t, d/Duration(time.Second)*Duration(time.Second), fromHMS, d.HMS())
+
+		v, err := d.Value()
+		require.NoError(t, err)
+		var fromValue Duration
+		require.NoError(t, fromValue.Scan(v))
+		assert.Equal(t, d, fromValue)
+
+		b, err = d.MarshalBinary()
+		require.NoError(t, err)
+		var fromBinary Duration
+		require.NoError(t, fromBinary.UnmarshalBinary(b))
+		assert.Equal(t, d, fromBinary)
+	})
+}
@@ -0,0 +1,62 @@
+package timetype
+
+import (
+	"context"
+	"io"
+	"strconv"
+)
+
+// MarshalGQL implements graphql.Marshaler of gqlgen and writes the clock
+// as a quoted string in the same format as MarshalJSON does. It can't report
+// errors, so the clock is truncated even in the strict precision mode,
+// see MarshalGQLContext.
+func (h Clock) MarshalGQL(w io.Writer) {
+	_, _ = io.WriteString(w, strconv.Quote(h.Format(defaultClockLayout)))
+}
+
+// MarshalGQLContext implements graphql.ContextMarshaler of gqlgen and writes
+// the clock as MarshalGQL does, but returns ErrPrecisionLoss in the strict
+// precision mode instead of truncating the clock
+func (h Clock) MarshalGQLContext(_ context.Context, w io.Writer) error {
+	if err := h.checkPrecision(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, strconv.Quote(h.Format(defaultClockLayout)))
+	return wrapExternalErr("marshal", "clock", err)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler of gqlgen and parses
+// the string clock in any of the known layouts
+func (h *Clock) UnmarshalGQL(v interface{}) error {
+	val, ok := v.(string)
+	if !ok {
+		return ErrInvalidClock
+	}
+	c, err := ParseClock(val)
+	if err != nil {
+		return err
+	}
+	*h = c
+	return nil
+}
+
+// MarshalGQL implements graphql.Marshaler of gqlgen and writes the duration
+// as a quoted string in the same format as MarshalJSON does
+func (d Duration) MarshalGQL(w io.Writer) {
+	_, _ = io.WriteString(w, strconv.Quote(d.String()))
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler of gqlgen and parses
+// the string duration, as ParseDuration does
+func (d *Duration) UnmarshalGQL(v interface{}) error {
+	val, ok := v.(string)
+	if !ok {
+		return ErrInvalidDuration
+	}
+	tmp, err := parseDurationString(val)
+	if err != nil {
+		return withErrOp("unmarshal", err)
+	}
+	*d = tmp
+	return nil
+}
@@ -0,0 +1,74 @@
+package timetype
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClock_MarshalGQL(t *testing.T) {
+	buf := &bytes.Buffer{}
+	NewUTCClock(19, 24, 5, 0).MarshalGQL(buf)
+	assert.Equal(t, `"19:24:05.000000"`, buf.String())
+}
+
+func TestClock_UnmarshalGQL(t *testing.T) {
+	tbl := []struct {
+		arg      interface{}
+		expected Clock
+		err      string
+	}{
+		{arg: "19:24:05", expected: NewUTCClock(19, 24, 5, 0)},
+		{arg: "7:24 PM", expected: NewUTCClock(19, 24, 0, 0)},
+		{arg: 5, err: "timetype: invalid clock"},
+		{arg: nil, err: "timetype: invalid clock"},
+		{arg: "abacaba", err: "timetype: failed to parse \"abacaba\" in layouts: " +
+			"[\"15:04:05\", \"15:04:05.000000\", \"15:04:05.000000000\", \"15:04:05Z07:00\", \"15:04:05.000000Z07:00\", \"3:04 PM\", \"3:04:05 PM\", \"15:04:05-07\", \"15:04:05.000000-07:00\", \"15:04\"]"},
+	}
+	for i, tt := range tbl {
+		var c Clock
+		err := c.UnmarshalGQL(tt.arg)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, "case #%d", i)
+			assert.Equal(t, Clock{}, c, "case #%d", i)
+			continue
+		}
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, c, "case #%d", i)
+	}
+}
+
+func TestDuration_MarshalGQL(t *testing.T) {
+	buf := &bytes.Buffer{}
+	Duration(time.Hour + 5*time.Minute + 3*time.Second).MarshalGQL(buf)
+	assert.Equal(t, `"1h5m3s"`, buf.String())
+}
+
+func TestDuration_UnmarshalGQL(t *testing.T) {
+	tbl := []struct {
+		arg      interface{}
+		expected Duration
+		err      string
+	}{
+		{arg: "1h5m3s", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: "7d", expected: Duration(168 * time.Hour)},
+		{arg: "PT1H", expected: Duration(time.Hour)},
+		{arg: 3903000000000, err: "timetype: invalid duration"},
+		{arg: true, err: "timetype: invalid duration"},
+		{arg: "1hour", err: "timetype: unmarshal duration: time: unknown unit \"hour\" in duration \"1hour\""},
+	}
+	for i, tt := range tbl {
+		var d Duration
+		err := d.UnmarshalGQL(tt.arg)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, "case #%d", i)
+			assert.Equal(t, Duration(0), d, "case #%d", i)
+			continue
+		}
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, d, "case #%d", i)
+	}
+}
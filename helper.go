@@ -27,3 +27,78 @@ func ParseWeekday(s string) (time.Weekday, error) {
 	}
 	return 0, ErrInvalidWeekday
 }
+
+// MinClock returns the earliest of the clocks by the time of the day, as Clock.Before
+// compares them, or the zero Clock if no clocks are given
+func MinClock(clocks ...Clock) Clock {
+	if len(clocks) == 0 {
+		return Clock{}
+	}
+	res := clocks[0]
+	for _, c := range clocks[1:] {
+		if c.Before(res) {
+			res = c
+		}
+	}
+	return res
+}
+
+// MaxClock returns the latest of the clocks by the time of the day, as Clock.After
+// compares them, or the zero Clock if no clocks are given
+func MaxClock(clocks ...Clock) Clock {
+	if len(clocks) == 0 {
+		return Clock{}
+	}
+	res := clocks[0]
+	for _, c := range clocks[1:] {
+		if c.After(res) {
+			res = c
+		}
+	}
+	return res
+}
+
+// MinDuration returns the shortest of the durations or zero if no durations are given
+func MinDuration(ds ...Duration) Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	res := ds[0]
+	for _, d := range ds[1:] {
+		if d < res {
+			res = d
+		}
+	}
+	return res
+}
+
+// MaxDuration returns the longest of the durations or zero if no durations are given
+func MaxDuration(ds ...Duration) Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	res := ds[0]
+	for _, d := range ds[1:] {
+		if d > res {
+			res = d
+		}
+	}
+	return res
+}
+
+// RangeClock calls fn for each clock from start to end, inclusive, with the given step,
+// e.g. 09:00, 09:30, ..., 12:00 for the 30m step. If the step doesn't divide the span
+// evenly, the last clock is the one before end. If end is before start, the range wraps
+// past midnight. The iteration stops early if fn returns false. No clocks are visited
+// if the step is not positive.
+func RangeClock(start, end Clock, step time.Duration, fn func(Clock) bool) {
+	if step <= 0 {
+		return
+	}
+	span, _ := start.DiffAcross(end)
+	for off := time.Duration(0); off <= span; off += step {
+		if !fn(start.Add(off)) {
+			return
+		}
+	}
+}
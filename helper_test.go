@@ -26,3 +26,101 @@ func TestParseWeekday(t *testing.T) {
 	assert.EqualError(t, err, "timetype: invalid weekday")
 	assert.Equal(t, ErrInvalidWeekday, err)
 }
+
+func TestMinMaxClock(t *testing.T) {
+	plus3 := time.FixedZone("", 3*60*60)
+	tbl := []struct {
+		arg      []Clock
+		min, max Clock
+	}{
+		{arg: nil, min: Clock{}, max: Clock{}},
+		{arg: []Clock{NewUTCClock(19, 24, 0, 0)}, min: NewUTCClock(19, 24, 0, 0), max: NewUTCClock(19, 24, 0, 0)},
+		{
+			arg: []Clock{NewUTCClock(12, 0, 0, 0), NewUTCClock(8, 30, 0, 0), NewUTCClock(23, 59, 0, 0), NewUTCClock(0, 15, 0, 0)},
+			min: NewUTCClock(0, 15, 0, 0),
+			max: NewUTCClock(23, 59, 0, 0),
+		},
+		{ // compared by the time of the day, regardless of the location
+			arg: []Clock{NewUTCClock(10, 0, 0, 0), NewClock(11, 0, 0, 0, plus3)},
+			min: NewUTCClock(10, 0, 0, 0),
+			max: NewClock(11, 0, 0, 0, plus3),
+		},
+		{ // the first one wins among equal clocks
+			arg: []Clock{NewUTCClock(10, 0, 0, 0), NewClock(10, 0, 0, 0, plus3)},
+			min: NewUTCClock(10, 0, 0, 0),
+			max: NewUTCClock(10, 0, 0, 0),
+		},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.min, MinClock(tt.arg...), "case #%d", i)
+		assert.Equal(t, tt.max, MaxClock(tt.arg...), "case #%d", i)
+	}
+}
+
+func TestMinMaxDuration(t *testing.T) {
+	tbl := []struct {
+		arg      []Duration
+		min, max Duration
+	}{
+		{arg: nil, min: 0, max: 0},
+		{arg: []Duration{Duration(time.Hour)}, min: Duration(time.Hour), max: Duration(time.Hour)},
+		{
+			arg: []Duration{Duration(time.Minute), Duration(-time.Hour), Duration(2 * time.Hour), 0},
+			min: Duration(-time.Hour),
+			max: Duration(2 * time.Hour),
+		},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.min, MinDuration(tt.arg...), "case #%d", i)
+		assert.Equal(t, tt.max, MaxDuration(tt.arg...), "case #%d", i)
+	}
+}
+
+func TestRangeClock(t *testing.T) {
+	collect := func(start, end Clock, step time.Duration, limit int) (res []string) {
+		RangeClock(start, end, step, func(c Clock) bool {
+			res = append(res, c.Format("15:04"))
+			return len(res) < limit
+		})
+		return res
+	}
+
+	tbl := []struct {
+		start, end Clock
+		step       time.Duration
+		limit      int
+		expected   []string
+	}{
+		{
+			start: NewUTCClock(9, 0, 0, 0), end: NewUTCClock(12, 0, 0, 0), step: 30 * time.Minute, limit: 100,
+			expected: []string{"09:00", "09:30", "10:00", "10:30", "11:00", "11:30", "12:00"},
+		},
+		{
+			start: NewUTCClock(9, 0, 0, 0), end: NewUTCClock(10, 0, 0, 0), step: 25 * time.Minute, limit: 100,
+			expected: []string{"09:00", "09:25", "09:50"},
+		},
+		{
+			start: NewUTCClock(9, 0, 0, 0), end: NewUTCClock(12, 0, 0, 0), step: 30 * time.Minute, limit: 3,
+			expected: []string{"09:00", "09:30", "10:00"},
+		},
+		{
+			start: NewUTCClock(23, 0, 0, 0), end: NewUTCClock(1, 0, 0, 0), step: 30 * time.Minute, limit: 100,
+			expected: []string{"23:00", "23:30", "00:00", "00:30", "01:00"},
+		},
+		{
+			start: NewUTCClock(9, 0, 0, 0), end: NewUTCClock(9, 0, 0, 0), step: time.Hour, limit: 100,
+			expected: []string{"09:00"},
+		},
+		{
+			start: NewUTCClock(9, 0, 0, 0), end: NewUTCClock(12, 0, 0, 0), step: 0, limit: 100,
+			expected: nil,
+		},
+		{
+			start: NewUTCClock(9, 0, 0, 0), end: NewUTCClock(12, 0, 0, 0), step: -time.Hour, limit: 100,
+			expected: nil,
+		},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, collect(tt.start, tt.end, tt.step, tt.limit), "case #%d", i)
+	}
+}
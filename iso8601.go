@@ -0,0 +1,200 @@
+package timetype
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrAmbiguousUnit is returned if the duration contains units with variable length,
+// like years or months
+var ErrAmbiguousUnit = errors.New("timetype: ambiguous duration unit")
+
+// iso8601Designators maps ISO 8601 duration designators to their lengths in nanoseconds.
+// Designators of the date part are listed first, designators of the time part are
+// listed after them, the order of designators in the duration string must be the same.
+var iso8601Designators = []struct {
+	des      byte
+	timePart bool
+	unit     uint64
+}{
+	{des: 'Y', timePart: false, unit: 0}, // years, ambiguous
+	{des: 'M', timePart: false, unit: 0}, // months, ambiguous
+	{des: 'W', timePart: false, unit: uint64(7 * day)},
+	{des: 'D', timePart: false, unit: uint64(day)},
+	{des: 'H', timePart: true, unit: uint64(60 * 60 * 1e9)},
+	{des: 'M', timePart: true, unit: uint64(60 * 1e9)},
+	{des: 'S', timePart: true, unit: uint64(1e9)},
+}
+
+// isISO8601Duration checks whether the value looks like an ISO 8601 duration
+func isISO8601Duration(s string) bool {
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+	return strings.HasPrefix(s, "P")
+}
+
+// ParseISO8601Duration parses the duration in ISO 8601 format, like "PT1H5M3S" or "P1DT2H".
+// Days are considered to be exactly 24 hours long and weeks are 7 days long, years and
+// months have no fixed length, so ErrAmbiguousUnit is returned for them. Only the last
+// component can have a fractional part, like "PT1.5S". The duration may be prefixed with
+// a sign, e.g. "-PT1H". ErrInvalidDuration is returned for malformed values.
+func ParseISO8601Duration(s string) (Duration, error) {
+	neg, s := cutSign(s)
+	if !strings.HasPrefix(s, "P") {
+		return 0, ErrInvalidDuration
+	}
+	datePart, timePart, hasTime := strings.Cut(s[1:], "T")
+	if (datePart == "" && !hasTime) || (hasTime && timePart == "") {
+		return 0, ErrInvalidDuration
+	}
+
+	date, fractional, err := iso8601Part(datePart, false)
+	if err != nil {
+		return 0, err
+	}
+	if fractional && hasTime { // fraction is allowed only in the last component
+		return 0, ErrInvalidDuration
+	}
+	clock, _, err := iso8601Part(timePart, true)
+	if err != nil {
+		return 0, err
+	}
+	if clock > 1<<63-date {
+		return 0, ErrInvalidDuration
+	}
+	return signedDuration(neg, date+clock)
+}
+
+// iso8601Part returns the amount of nanoseconds in the date or the time part of the
+// ISO 8601 duration, like "1W2D" or "1H5M3S", and whether its last component has
+// a fractional part
+func iso8601Part(s string, timePart bool) (total uint64, fractional bool, err error) {
+	pos := 0
+	for s != "" {
+		if fractional { // fraction is allowed only in the last component
+			return 0, false, ErrInvalidDuration
+		}
+		num, des, rest := cutComponent(s)
+		s = rest
+		if len(des) != 1 {
+			return 0, false, ErrInvalidDuration
+		}
+
+		var unit uint64
+		if unit, pos = iso8601Unit(des[0], timePart, pos); pos < 0 {
+			return 0, false, ErrInvalidDuration
+		}
+		if unit == 0 {
+			return 0, false, ErrAmbiguousUnit
+		}
+
+		v, err := iso8601Component(num, unit)
+		if err != nil {
+			return 0, false, err
+		}
+		if v > 1<<63-total {
+			return 0, false, ErrInvalidDuration
+		}
+		total += v
+		fractional = strings.ContainsAny(num, ".,")
+	}
+	return total, fractional, nil
+}
+
+// iso8601Unit looks up the designator of the date or the time part in iso8601Designators,
+// starting at pos, as designators must follow in order, and returns its length and the
+// position to look up the next designator at, or -1, if the designator is not found
+func iso8601Unit(des byte, timePart bool, pos int) (unit uint64, next int) {
+	for ; pos < len(iso8601Designators); pos++ {
+		if d := iso8601Designators[pos]; d.des == des && d.timePart == timePart {
+			return d.unit, pos + 1
+		}
+	}
+	return 0, -1
+}
+
+// iso8601Component returns the amount of nanoseconds in the single component
+// of the duration with the given number, like "1" or "1.5", in the given unit
+func iso8601Component(num string, unit uint64) (uint64, error) {
+	if num == "" {
+		return 0, ErrInvalidDuration
+	}
+	intPart, fracPart := num, ""
+	if i := strings.IndexAny(num, ".,"); i >= 0 {
+		intPart, fracPart = num[:i], num[i+1:]
+		if intPart == "" && fracPart == "" || fracPart != "" && !isDigits(fracPart) {
+			return 0, ErrInvalidDuration
+		}
+	}
+
+	var v uint64
+	if intPart != "" {
+		n, err := strconv.ParseUint(intPart, 10, 64)
+		if err != nil || n > (1<<63)/unit {
+			return 0, ErrInvalidDuration
+		}
+		v = n * unit
+	}
+	// fractional part is applied digit by digit to avoid float rounding
+	scale := unit
+	for i := 0; i < len(fracPart) && scale > 0; i++ {
+		scale /= 10
+		v += uint64(fracPart[i]-'0') * scale
+	}
+	return v, nil
+}
+
+// ISO8601 returns the duration in ISO 8601 format, like "PT1H5M3S".
+// The duration is decomposed into hours, minutes and seconds only,
+// use ISO8601WithDays to also extract days from the hours.
+func (d Duration) ISO8601() string {
+	return d.formatISO8601(false)
+}
+
+// ISO8601WithDays returns the duration in ISO 8601 format with days, considering
+// the day to be exactly 24 hours long, e.g. "P1DT2H" for 26 hours.
+func (d Duration) ISO8601WithDays() string {
+	return d.formatISO8601(true)
+}
+
+func (d Duration) formatISO8601(withDays bool) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	u := uint64(d)
+	b := make([]byte, 0, 32)
+	if d < 0 {
+		u = -u
+		b = append(b, '-')
+	}
+	b = append(b, 'P')
+
+	secs, frac := u/1e9, u%1e9
+	if days := secs / (24 * 60 * 60); withDays && days > 0 {
+		b = append(strconv.AppendUint(b, days, 10), 'D')
+		secs %= 24 * 60 * 60
+	}
+	if secs == 0 && frac == 0 {
+		return string(b)
+	}
+
+	b = append(b, 'T')
+	if hours := secs / (60 * 60); hours > 0 {
+		b = append(strconv.AppendUint(b, hours, 10), 'H')
+	}
+	if minutes := secs / 60 % 60; minutes > 0 {
+		b = append(strconv.AppendUint(b, minutes, 10), 'M')
+	}
+	if secs%60 > 0 || frac > 0 {
+		b = strconv.AppendUint(b, secs%60, 10)
+		if frac > 0 {
+			digits := strconv.AppendUint(make([]byte, 0, 9), frac+1e9, 10)[1:] // zero-padded to 9 digits
+			b = append(append(b, '.'), strings.TrimRight(string(digits), "0")...)
+		}
+		b = append(b, 'S')
+	}
+	return string(b)
+}
@@ -0,0 +1,108 @@
+package timetype
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseISO8601Duration(t *testing.T) {
+	tbl := []struct {
+		arg      string
+		expected Duration
+		err      error
+	}{
+		{arg: "PT1H5M3S", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: "PT5M", expected: Duration(5 * time.Minute)},
+		{arg: "PT0S", expected: Duration(0)},
+		{arg: "P1DT2H", expected: Duration(26 * time.Hour)},
+		{arg: "P2D", expected: Duration(48 * time.Hour)},
+		{arg: "P1W", expected: Duration(7 * 24 * time.Hour)},
+		{arg: "PT1.5S", expected: Duration(1500 * time.Millisecond)},
+		{arg: "PT0,000000001S", expected: Duration(1)},
+		{arg: "PT1M0.25S", expected: Duration(time.Minute + 250*time.Millisecond)},
+		{arg: "PT0.5H", expected: Duration(30 * time.Minute)},
+		{arg: "-PT1H", expected: Duration(-time.Hour)},
+		{arg: "+PT1H", expected: Duration(time.Hour)},
+		{arg: "PT2562047H47M16.854775807S", expected: Duration(math.MaxInt64)},
+		{arg: "-PT2562047H47M16.854775808S", expected: Duration(math.MinInt64)},
+		{arg: "P1Y", err: ErrAmbiguousUnit},
+		{arg: "P2M", err: ErrAmbiguousUnit},
+		{arg: "P1Y2M3DT4H", err: ErrAmbiguousUnit},
+		{arg: "PT2562047H47M16.854775808S", err: ErrInvalidDuration},
+		{arg: "PT99999999999999999999H", err: ErrInvalidDuration},
+		{arg: "", err: ErrInvalidDuration},
+		{arg: "P", err: ErrInvalidDuration},
+		{arg: "PT", err: ErrInvalidDuration},
+		{arg: "P1DT", err: ErrInvalidDuration},
+		{arg: "1H", err: ErrInvalidDuration},
+		{arg: "PT1H5M3", err: ErrInvalidDuration},
+		{arg: "PT3S5M", err: ErrInvalidDuration},
+		{arg: "PT1.5M3S", err: ErrInvalidDuration},
+		{arg: "P1H", err: ErrInvalidDuration},
+		{arg: "PT1D", err: ErrInvalidDuration},
+		{arg: "PTT1H", err: ErrInvalidDuration},
+		{arg: "PT.S", err: ErrInvalidDuration},
+		{arg: "PTH", err: ErrInvalidDuration},
+		{arg: "PT1.2.3S", err: ErrInvalidDuration},
+		{arg: "P1.5DT1H", err: ErrInvalidDuration},
+		{arg: "PT1HT2M", err: ErrInvalidDuration},
+		{arg: "P1D2", err: ErrInvalidDuration},
+	}
+	for i, tt := range tbl {
+		d, err := ParseISO8601Duration(tt.arg)
+		if tt.err != nil {
+			assert.Equal(t, tt.err, err, "case #%d", i)
+			continue
+		}
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, d, "case #%d", i)
+	}
+}
+
+func TestDuration_UnmarshalJSON_ISO8601(t *testing.T) {
+	var d Duration
+	require.NoError(t, d.UnmarshalJSON([]byte(`"PT1H5M3S"`)))
+	assert.Equal(t, Duration(time.Hour+5*time.Minute+3*time.Second), d)
+
+	require.NoError(t, d.UnmarshalJSON([]byte(`"P1DT2H"`)))
+	assert.Equal(t, Duration(26*time.Hour), d)
+
+	require.NoError(t, d.UnmarshalJSON([]byte(`"PT0.5S"`)))
+	assert.Equal(t, Duration(500*time.Millisecond), d)
+
+	assert.Equal(t, ErrAmbiguousUnit, d.UnmarshalJSON([]byte(`"P1M"`)))
+}
+
+func TestDuration_ISO8601(t *testing.T) {
+	tbl := []struct {
+		arg      Duration
+		expected string
+		withDays string
+	}{
+		{arg: 0, expected: "PT0S", withDays: "PT0S"},
+		{arg: Duration(time.Hour + 5*time.Minute + 3*time.Second), expected: "PT1H5M3S", withDays: "PT1H5M3S"},
+		{arg: Duration(-(time.Hour + 5*time.Minute + 3*time.Second)), expected: "-PT1H5M3S", withDays: "-PT1H5M3S"},
+		{arg: Duration(1500 * time.Millisecond), expected: "PT1.5S", withDays: "PT1.5S"},
+		{arg: Duration(time.Nanosecond), expected: "PT0.000000001S", withDays: "PT0.000000001S"},
+		{arg: Duration(26 * time.Hour), expected: "PT26H", withDays: "P1DT2H"},
+		{arg: Duration(48 * time.Hour), expected: "PT48H", withDays: "P2D"},
+		{arg: Duration(100*time.Hour + 30*time.Second), expected: "PT100H30S", withDays: "P4DT4H30S"},
+		{arg: Duration(math.MinInt64), expected: "-PT2562047H47M16.854775808S", withDays: "-P106751DT23H47M16.854775808S"},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.ISO8601(), "case #%d", i)
+		assert.Equal(t, tt.withDays, tt.arg.ISO8601WithDays(), "case #%d", i)
+
+		// round trip
+		d, err := ParseISO8601Duration(tt.arg.ISO8601())
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.arg, d, "case #%d", i)
+		d, err = ParseISO8601Duration(tt.arg.ISO8601WithDays())
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.arg, d, "case #%d", i)
+	}
+}
@@ -0,0 +1,117 @@
+package timetype
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"time"
+)
+
+// NullClock is a Clock, that may be null, as sql.NullTime.
+// Valid is false if the clock is SQL NULL or JSON null.
+type NullClock struct {
+	Clock Clock
+	Valid bool
+}
+
+// Scan the given SQL value as NullClock
+func (n *NullClock) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*n = NullClock{}
+		return nil
+	case sql.NullTime:
+		if !v.Valid {
+			*n = NullClock{}
+			return nil
+		}
+	case *time.Time:
+		if v == nil {
+			*n = NullClock{}
+			return nil
+		}
+	}
+	if err := n.Clock.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value returns the SQL value of the given NullClock, nil if it's not valid
+func (n NullClock) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Clock.Value()
+}
+
+// MarshalJSON marshals the clock as Clock does or into null, if it's not valid
+func (n NullClock) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Clock.MarshalJSON()
+}
+
+// UnmarshalJSON parses the clock as Clock does, JSON null makes it not valid
+func (n *NullClock) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*n = NullClock{}
+		return nil
+	}
+	if err := n.Clock.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// NullDuration is a Duration, that may be null, as sql.NullInt64.
+// Valid is false if the duration is SQL NULL or JSON null.
+type NullDuration struct {
+	Duration Duration
+	Valid    bool
+}
+
+// Scan the given SQL value as NullDuration
+func (n *NullDuration) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullDuration{}
+		return nil
+	}
+	if err := n.Duration.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value returns the SQL value of the given NullDuration, nil if it's not valid
+func (n NullDuration) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Duration.Value()
+}
+
+// MarshalJSON marshals the duration as Duration does or into null, if it's not valid
+func (n NullDuration) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Duration.MarshalJSON()
+}
+
+// UnmarshalJSON parses the duration as Duration does, JSON null makes it not valid
+func (n *NullDuration) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*n = NullDuration{}
+		return nil
+	}
+	if err := n.Duration.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
@@ -0,0 +1,216 @@
+package timetype
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNullClock_Scan(t *testing.T) {
+	tbl := []struct {
+		arg      interface{}
+		expected NullClock
+		err      string
+	}{
+		{
+			arg:      nil,
+			expected: NullClock{},
+		},
+		{
+			arg:      time.Date(0, time.January, 1, 2, 19, 30, 0, time.UTC),
+			expected: NullClock{Clock: NewUTCClock(2, 19, 30, 0), Valid: true},
+		},
+		{
+			arg:      sql.NullTime{Time: time.Date(0, time.January, 1, 2, 19, 30, 0, time.UTC), Valid: true},
+			expected: NullClock{Clock: NewUTCClock(2, 19, 30, 0), Valid: true},
+		},
+		{
+			arg:      sql.NullTime{},
+			expected: NullClock{},
+		},
+		{
+			arg:      (*time.Time)(nil),
+			expected: NullClock{},
+		},
+		{
+			arg:      `19:24:00.000000`,
+			expected: NullClock{Clock: NewUTCClock(19, 24, 0, 0), Valid: true},
+		},
+		{
+			arg:      []byte(`00:00:00`),
+			expected: NullClock{Clock: NewUTCClock(0, 0, 0, 0), Valid: true},
+		},
+		{
+			arg:      true,
+			expected: NullClock{},
+			err:      "timetype: invalid clock",
+		},
+	}
+
+	for i, tt := range tbl {
+		c := NullClock{}
+		err := c.Scan(tt.arg)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, "case #%d", i)
+		} else {
+			assert.NoError(t, err, "case #%d", i)
+		}
+		assert.Equal(t, tt.expected, c, "case #%d", i)
+	}
+
+	c := NullClock{Clock: NewUTCClock(19, 24, 0, 0), Valid: true}
+	require.NoError(t, c.Scan(nil))
+	assert.Equal(t, NullClock{}, c)
+}
+
+func TestNullClock_Value(t *testing.T) {
+	tbl := []struct {
+		arg      NullClock
+		expected driver.Value
+	}{
+		{arg: NullClock{}, expected: nil},
+		{arg: NullClock{Clock: NewUTCClock(19, 24, 0, 0)}, expected: nil},
+		{arg: NullClock{Clock: NewUTCClock(19, 24, 0, 0), Valid: true}, expected: driver.Value(`19:24:00.000000`)},
+		{arg: NullClock{Clock: NewUTCClock(0, 0, 0, 0), Valid: true}, expected: driver.Value(`00:00:00.000000`)},
+	}
+
+	for i, tt := range tbl {
+		actual, err := tt.arg.Value()
+		assert.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, actual, "case #%d", i)
+	}
+}
+
+func TestNullClock_JSON(t *testing.T) {
+	tbl := []struct {
+		arg      NullClock
+		expected string
+	}{
+		{arg: NullClock{}, expected: `null`},
+		{arg: NullClock{Clock: NewUTCClock(19, 24, 0, 0), Valid: true}, expected: `"19:24:00.000000"`},
+		{arg: NullClock{Clock: NewUTCClock(0, 0, 0, 0), Valid: true}, expected: `"00:00:00.000000"`},
+	}
+
+	for i, tt := range tbl {
+		b, err := json.Marshal(tt.arg)
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, string(b), "case #%d", i)
+
+		c := NullClock{Clock: NewUTCClock(1, 0, 0, 0), Valid: true}
+		require.NoError(t, json.Unmarshal(b, &c), "case #%d", i)
+		assert.Equal(t, tt.arg, c, "case #%d", i)
+	}
+
+	var s struct {
+		C *NullClock `json:"c"`
+		D NullClock  `json:"d"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"c": "7:24 PM", "d": null}`), &s))
+	assert.Equal(t, &NullClock{Clock: NewUTCClock(19, 24, 0, 0), Valid: true}, s.C)
+	assert.Equal(t, NullClock{}, s.D)
+
+	var c NullClock
+	assert.Equal(t, ErrInvalidClock, c.UnmarshalJSON([]byte(`5`)))
+	assert.False(t, c.Valid)
+}
+
+func TestNullDuration_Scan(t *testing.T) {
+	tbl := []struct {
+		arg      interface{}
+		expected NullDuration
+		err      string
+	}{
+		{
+			arg:      nil,
+			expected: NullDuration{},
+		},
+		{
+			arg:      int64(0),
+			expected: NullDuration{Valid: true},
+		},
+		{
+			arg:      int64(2*time.Hour + 3*time.Minute),
+			expected: NullDuration{Duration: Duration(2*time.Hour + 3*time.Minute), Valid: true},
+		},
+		{
+			arg:      `"5h3m2s"`,
+			expected: NullDuration{Duration: Duration(5*time.Hour + 3*time.Minute + 2*time.Second), Valid: true},
+		},
+		{
+			arg:      []byte(`2h3m`),
+			expected: NullDuration{Duration: Duration(2*time.Hour + 3*time.Minute), Valid: true},
+		},
+		{
+			arg:      'c',
+			expected: NullDuration{},
+			err:      "timetype: invalid duration",
+		},
+	}
+
+	for i, tt := range tbl {
+		d := NullDuration{}
+		err := d.Scan(tt.arg)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, "case #%d", i)
+		} else {
+			assert.NoError(t, err, "case #%d", i)
+		}
+		assert.Equal(t, tt.expected, d, "case #%d", i)
+	}
+
+	d := NullDuration{Duration: Duration(time.Second), Valid: true}
+	require.NoError(t, d.Scan(nil))
+	assert.Equal(t, NullDuration{}, d)
+}
+
+func TestNullDuration_Value(t *testing.T) {
+	tbl := []struct {
+		arg      NullDuration
+		expected driver.Value
+	}{
+		{arg: NullDuration{}, expected: nil},
+		{arg: NullDuration{Duration: Duration(time.Second)}, expected: nil},
+		{arg: NullDuration{Valid: true}, expected: int64(0)},
+		{arg: NullDuration{Duration: Duration(2*time.Hour + 3*time.Minute), Valid: true}, expected: int64(2*time.Hour + 3*time.Minute)},
+	}
+
+	for i, tt := range tbl {
+		actual, err := tt.arg.Value()
+		assert.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, actual, "case #%d", i)
+	}
+}
+
+func TestNullDuration_JSON(t *testing.T) {
+	tbl := []struct {
+		arg      NullDuration
+		expected string
+	}{
+		{arg: NullDuration{}, expected: `null`},
+		{arg: NullDuration{Valid: true}, expected: `"0s"`},
+		{arg: NullDuration{Duration: Duration(time.Hour + 5*time.Minute + 3*time.Second), Valid: true}, expected: `"1h5m3s"`},
+	}
+
+	for i, tt := range tbl {
+		b, err := json.Marshal(tt.arg)
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, string(b), "case #%d", i)
+
+		d := NullDuration{Duration: Duration(time.Second), Valid: true}
+		require.NoError(t, json.Unmarshal(b, &d), "case #%d", i)
+		assert.Equal(t, tt.arg, d, "case #%d", i)
+	}
+
+	var d NullDuration
+	require.NoError(t, d.UnmarshalJSON([]byte(`"1h5m3s"`)))
+	assert.Equal(t, NullDuration{Duration: Duration(time.Hour + 5*time.Minute + 3*time.Second), Valid: true}, d)
+
+	d = NullDuration{}
+	assert.Equal(t, ErrInvalidDuration, d.UnmarshalJSON([]byte(`true`)))
+	assert.False(t, d.Valid)
+}
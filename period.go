@@ -0,0 +1,242 @@
+package timetype
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidPeriod is returned when the value can't be decoded as Period
+var ErrInvalidPeriod = errors.New("timetype: invalid period")
+
+// Period is a range of the time of the day between two clocks, like business hours.
+// The range is half-open, Start is included and End is excluded. If End is before
+// Start, the period wraps past midnight, e.g. 22:00-02:00 contains 23:00 and 01:00.
+// Period with equal Start and End is empty. Clocks are compared by their wall clock
+// in their own locations, as Clock.Before does.
+type Period struct {
+	Start Clock `json:"start"`
+	End   Clock `json:"end"`
+}
+
+// Contains reports whether the clock is inside the period
+func (p Period) Contains(c Clock) bool {
+	start, end, off := p.Start.sinceMidnight(), p.End.sinceMidnight(), c.sinceMidnight()
+	if start <= end {
+		return start <= off && off < end
+	}
+	return off >= start || off < end
+}
+
+// Overlaps reports whether the periods have any time of the day in common.
+// Empty periods never overlap.
+func (p Period) Overlaps(other Period) bool {
+	for _, a := range p.segments() {
+		for _, b := range other.segments() {
+			if a[0] < b[1] && b[0] < a[1] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Duration returns the length of the period, it is less than 24 hours, unless
+// the period ends at the end of the day, as 00:00-24:00 does
+func (p Period) Duration() time.Duration {
+	d, _ := p.Start.DiffAcross(p.End)
+	return d
+}
+
+// wraps reports whether the period wraps past midnight
+func (p Period) wraps() bool {
+	return p.End.Before(p.Start)
+}
+
+// segments splits the period into non-wrapping [start, end) offsets from midnight
+func (p Period) segments() [][2]time.Duration {
+	start, end := p.Start.sinceMidnight(), p.End.sinceMidnight()
+	switch {
+	case start == end:
+		return nil
+	case start < end:
+		return [][2]time.Duration{{start, end}}
+	case end == 0:
+		return [][2]time.Duration{{start, day}}
+	default:
+		return [][2]time.Duration{{start, day}, {0, end}}
+	}
+}
+
+// periodJSON is the object form of the period, that doesn't use the text form of Period
+type periodJSON struct {
+	Start Clock `json:"start"`
+	End   Clock `json:"end"`
+}
+
+// MarshalJSON marshals the period into the object, like {"start":"09:00:00.000000",...},
+// the text form is not used in JSON
+func (p Period) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(periodJSON(p))
+	return b, wrapExternalErr("marshal", "period", err)
+}
+
+// UnmarshalJSON parses the object with start and end clocks, as Clock does.
+// JSON null leaves the period unchanged.
+func (p *Period) UnmarshalJSON(b []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return wrapExternalErr("unmarshal", "period", err)
+	}
+	switch raw.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+	default:
+		return ErrInvalidPeriod
+	}
+	var v periodJSON
+	if err := json.Unmarshal(b, &v); err != nil { // only errors of clocks are left
+		return err
+	}
+	*p = Period(v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and marshals the period into start and
+// end clocks, as Clock.MarshalText does, separated by "-", like "09:00:00.000000-17:00:00.000000"
+func (p Period) MarshalText() ([]byte, error) {
+	start, err := p.Start.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	end, err := p.End.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return append(append(start, '-'), end...), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and parses start and end clocks,
+// separated by "-", like "09:00:00-17:00:00", each of them as ParseClock does. Clocks
+// may have negative zone offsets, like "09:00:00-07:00-17:00:00-07:00". The error
+// mentions the side of the period, that failed to parse.
+func (p *Period) UnmarshalText(b []byte) error {
+	s := string(b)
+	var startErr, endErr error
+	for i := 1; i < len(s); i++ {
+		if s[i] != '-' {
+			continue
+		}
+		start, err := ParseClock(s[:i])
+		if err != nil {
+			startErr = err
+			continue
+		}
+		end, err := ParseClock(s[i+1:])
+		if err != nil {
+			if endErr == nil {
+				endErr = err
+			}
+			continue
+		}
+		*p = Period{Start: start, End: end}
+		return nil
+	}
+
+	switch {
+	case endErr != nil:
+		return fmt.Errorf("period end: %w", endErr)
+	case startErr != nil:
+		return fmt.Errorf("period start: %w", startErr)
+	}
+	// no separator, the whole value is considered to be the start
+	if _, err := ParseClock(s); err != nil {
+		return fmt.Errorf("period start: %w", err)
+	}
+	return fmt.Errorf("period end: %w", &UnknownFormatError{Layouts: ClockLayouts()})
+}
+
+// Scan the given SQL value as Period, the value is parsed from the text form, as
+// UnmarshalText does, NULL is scanned as the zero period
+func (p *Period) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*p = Period{}
+		return nil
+	case string:
+		return p.UnmarshalText([]byte(v))
+	case []byte:
+		return p.UnmarshalText(v)
+	default:
+		return ErrInvalidPeriod
+	}
+}
+
+// Value returns the SQL value of the period in the text form, as MarshalText does
+func (p Period) Value() (driver.Value, error) {
+	b, err := p.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// WeeklyHours is a weekly schedule, like opening hours, with a period for each
+// open weekday. Periods wrapping past midnight continue into the next weekday,
+// e.g. Friday 22:00-02:00 is open until 02:00 on Saturday.
+type WeeklyHours map[time.Weekday]Period
+
+// IsOpen reports whether the given time falls into the period of its weekday or into
+// the wrapping period of the previous weekday. The weekday and the time of the day are
+// taken in the location of t.
+func (w WeeklyHours) IsOpen(t time.Time) bool {
+	c := clockOf(t)
+	if p, ok := w[t.Weekday()]; ok && p.Contains(c) && !(p.wraps() && c.Before(p.Start)) {
+		return true
+	}
+	prev, ok := w[(t.Weekday()+6)%7]
+	return ok && prev.wraps() && c.Before(prev.End)
+}
+
+// MarshalJSON marshals the schedule into the object keyed by weekday names, like "Monday"
+func (w WeeklyHours) MarshalJSON() ([]byte, error) {
+	res := make(map[string]Period, len(w))
+	for wd, p := range w {
+		res[wd.String()] = p
+	}
+	b, err := json.Marshal(res)
+	return b, wrapExternalErr("marshal", "weekly hours", err)
+}
+
+// UnmarshalJSON parses the object keyed by weekday names, as ParseWeekday does
+func (w *WeeklyHours) UnmarshalJSON(b []byte) error {
+	var v map[string]json.RawMessage
+	if err := json.Unmarshal(b, &v); err != nil {
+		return wrapExternalErr("unmarshal", "weekly hours", err)
+	}
+	res := make(WeeklyHours, len(v))
+	for k, raw := range v {
+		wd, err := ParseWeekday(k)
+		if err != nil {
+			return err
+		}
+		var p Period
+		if err = json.Unmarshal(raw, &p); err != nil {
+			return err
+		}
+		res[wd] = p
+	}
+	*w = res
+	return nil
+}
+
+// ClocksOverlap reports whether the ranges of the time of the day [aStart, aEnd) and
+// [bStart, bEnd) have any time in common, as Period.Overlaps does. Ranges are half-open
+// and may wrap past midnight, so ranges that only touch, like 09:00-12:00 and
+// 12:00-14:00, don't overlap, and empty ranges never overlap.
+func ClocksOverlap(aStart, aEnd, bStart, bEnd Clock) bool {
+	return Period{Start: aStart, End: aEnd}.Overlaps(Period{Start: bStart, End: bEnd})
+}
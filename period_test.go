@@ -0,0 +1,306 @@
+package timetype
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeriod_Contains(t *testing.T) {
+	business := Period{Start: NewUTCClock(9, 0, 0, 0), End: NewUTCClock(18, 0, 0, 0)}
+	night := Period{Start: NewUTCClock(22, 0, 0, 0), End: NewUTCClock(2, 0, 0, 0)}
+	tillMidnight := Period{Start: NewUTCClock(22, 0, 0, 0), End: NewUTCClock(0, 0, 0, 0)}
+	empty := Period{Start: NewUTCClock(9, 0, 0, 0), End: NewUTCClock(9, 0, 0, 0)}
+
+	tbl := []struct {
+		period   Period
+		arg      Clock
+		expected bool
+	}{
+		{period: business, arg: NewUTCClock(12, 30, 0, 0), expected: true},
+		{period: business, arg: NewUTCClock(9, 0, 0, 0), expected: true},
+		{period: business, arg: NewUTCClock(17, 59, 59, 999999999), expected: true},
+		{period: business, arg: NewUTCClock(18, 0, 0, 0), expected: false},
+		{period: business, arg: NewUTCClock(8, 59, 59, 0), expected: false},
+		{period: business, arg: NewUTCClock(23, 0, 0, 0), expected: false},
+		{period: night, arg: NewUTCClock(23, 0, 0, 0), expected: true},
+		{period: night, arg: NewUTCClock(0, 0, 0, 0), expected: true},
+		{period: night, arg: NewUTCClock(1, 59, 59, 0), expected: true},
+		{period: night, arg: NewUTCClock(22, 0, 0, 0), expected: true},
+		{period: night, arg: NewUTCClock(2, 0, 0, 0), expected: false},
+		{period: night, arg: NewUTCClock(12, 0, 0, 0), expected: false},
+		{period: tillMidnight, arg: NewUTCClock(23, 59, 59, 0), expected: true},
+		{period: tillMidnight, arg: NewUTCClock(0, 0, 0, 0), expected: false},
+		{period: empty, arg: NewUTCClock(9, 0, 0, 0), expected: false},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.period.Contains(tt.arg), "case #%d", i)
+	}
+}
+
+func TestPeriod_Overlaps(t *testing.T) {
+	period := func(sh, eh int) Period {
+		return Period{Start: NewUTCClock(sh, 0, 0, 0), End: NewUTCClock(eh, 0, 0, 0)}
+	}
+
+	tbl := []struct {
+		a, b     Period
+		expected bool
+	}{
+		{a: period(9, 12), b: period(11, 14), expected: true},
+		{a: period(9, 12), b: period(10, 11), expected: true},
+		{a: period(9, 12), b: period(12, 14), expected: false},
+		{a: period(9, 12), b: period(13, 14), expected: false},
+		{a: period(22, 2), b: period(1, 3), expected: true},
+		{a: period(22, 2), b: period(21, 23), expected: true},
+		{a: period(22, 2), b: period(2, 22), expected: false},
+		{a: period(22, 2), b: period(23, 1), expected: true},
+		{a: period(22, 0), b: period(0, 1), expected: false},
+		{a: period(9, 9), b: period(8, 10), expected: false},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.a.Overlaps(tt.b), "case #%d", i)
+		assert.Equal(t, tt.expected, tt.b.Overlaps(tt.a), "case #%d", i)
+	}
+}
+
+func TestPeriod_Duration(t *testing.T) {
+	tbl := []struct {
+		arg      Period
+		expected time.Duration
+	}{
+		{arg: Period{Start: NewUTCClock(9, 0, 0, 0), End: NewUTCClock(18, 30, 0, 0)}, expected: 9*time.Hour + 30*time.Minute},
+		{arg: Period{Start: NewUTCClock(22, 0, 0, 0), End: NewUTCClock(2, 0, 0, 0)}, expected: 4 * time.Hour},
+		{arg: Period{Start: NewUTCClock(22, 0, 0, 0), End: NewUTCClock(0, 0, 0, 0)}, expected: 2 * time.Hour},
+		{arg: Period{Start: NewUTCClock(9, 0, 0, 0), End: NewUTCClock(9, 0, 0, 0)}, expected: 0},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.Duration(), "case #%d", i)
+	}
+}
+
+func TestPeriod_JSON(t *testing.T) {
+	p := Period{Start: NewUTCClock(22, 0, 0, 0), End: NewUTCClock(2, 0, 0, 0)}
+	b, err := json.Marshal(p)
+	require.NoError(t, err)
+	assert.Equal(t, `{"start":"22:00:00.000000","end":"02:00:00.000000"}`, string(b))
+
+	var res Period
+	require.NoError(t, json.Unmarshal([]byte(`{"start": "10:00 PM", "end": "02:00:00"}`), &res))
+	assert.Equal(t, p, res)
+
+	assert.Equal(t, ErrInvalidClock, json.Unmarshal([]byte(`{"start": 5}`), &res))
+	assert.Equal(t, ErrInvalidPeriod, json.Unmarshal([]byte(`["10:00", "11:00"]`), &res))
+	assert.Equal(t, ErrInvalidPeriod, res.UnmarshalJSON([]byte(`"09:00-17:00"`)))
+	require.NoError(t, res.UnmarshalJSON([]byte(`null`)))
+	assert.Equal(t, p, res)
+
+	err = res.UnmarshalJSON([]byte(`{"start":`))
+	require.Error(t, err)
+	assert.IsType(t, &errExternal{}, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "timetype: unmarshal period: "), err.Error())
+	assert.NotContains(t, err.Error(), "periodJSON")
+}
+
+func TestPeriod_SQL(t *testing.T) {
+	tbl := []struct {
+		arg      interface{}
+		expected Period
+		value    driver.Value
+	}{
+		{
+			arg:      "22:00:00.000000-02:00:00.000000",
+			expected: Period{Start: NewUTCClock(22, 0, 0, 0), End: NewUTCClock(2, 0, 0, 0)},
+			value:    "22:00:00.000000-02:00:00.000000",
+		},
+		{
+			arg:      []byte("09:00-17:30"),
+			expected: Period{Start: NewUTCClock(9, 0, 0, 0), End: NewUTCClock(17, 30, 0, 0)},
+			value:    "09:00:00.000000-17:30:00.000000",
+		},
+		{arg: nil, expected: Period{}, value: "00:00:00.000000-00:00:00.000000"},
+	}
+	for i, tt := range tbl {
+		p := Period{Start: NewUTCClock(1, 0, 0, 0)}
+		require.NoError(t, p.Scan(tt.arg), "case #%d", i)
+		assert.Equal(t, tt.expected, p, "case #%d", i)
+
+		v, err := p.Value()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.value, v, "case #%d", i)
+	}
+
+	var p Period
+	assert.Equal(t, ErrInvalidPeriod, p.Scan(5))
+	err := p.Scan("09:00:00-25:00:00")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "period end")
+}
+
+func TestPeriod_Text(t *testing.T) {
+	minus7 := time.FixedZone("", -7*60*60)
+	tbl := []struct {
+		arg      string
+		expected Period
+		text     string
+	}{
+		{
+			arg:      "09:00:00-17:00:00",
+			expected: Period{Start: NewUTCClock(9, 0, 0, 0), End: NewUTCClock(17, 0, 0, 0)},
+			text:     "09:00:00.000000-17:00:00.000000",
+		},
+		{
+			arg:      "22:00:00.000000-02:00:00.000000",
+			expected: Period{Start: NewUTCClock(22, 0, 0, 0), End: NewUTCClock(2, 0, 0, 0)},
+			text:     "22:00:00.000000-02:00:00.000000",
+		},
+		{
+			arg:      "09:00:00-07:00-17:00:00-07:00",
+			expected: Period{Start: NewClock(9, 0, 0, 0, minus7), End: NewClock(17, 0, 0, 0, minus7)},
+			text:     "09:00:00.000000-17:00:00.000000",
+		},
+		{
+			arg:      "9:00 AM - 5:00 PM",
+			expected: Period{Start: NewUTCClock(9, 0, 0, 0), End: NewUTCClock(17, 0, 0, 0)},
+			text:     "09:00:00.000000-17:00:00.000000",
+		},
+	}
+	for i, tt := range tbl {
+		var p Period
+		require.NoError(t, p.UnmarshalText([]byte(tt.arg)), "case #%d", i)
+		assert.True(t, tt.expected.Start.Equal(p.Start), "case #%d", i)
+		assert.True(t, tt.expected.End.Equal(p.End), "case #%d", i)
+
+		b, err := p.MarshalText()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.text, string(b), "case #%d", i)
+	}
+
+	errTbl := []struct {
+		arg  string
+		side string
+		val  string
+	}{
+		{arg: "09:00:00", side: "period end", val: ""},
+		{arg: "abacaba", side: "period start", val: "abacaba"},
+		{arg: "25:00:00-17:00:00", side: "period start", val: "25:00:00"},
+		{arg: "09:00:00-17:61:00", side: "period end", val: "17:61:00"},
+		{arg: "09:00:00-", side: "period end", val: ""},
+	}
+	for i, tt := range errTbl {
+		p := Period{Start: NewUTCClock(1, 0, 0, 0)}
+		err := p.UnmarshalText([]byte(tt.arg))
+		require.Error(t, err, "case #%d", i)
+		assert.True(t, strings.HasPrefix(err.Error(), tt.side+": "), "case #%d: %v", i, err)
+		var ue *UnknownFormatError
+		require.True(t, errors.As(err, &ue), "case #%d", i)
+		assert.Equal(t, tt.val, ue.Val, "case #%d", i)
+		assert.Equal(t, Period{Start: NewUTCClock(1, 0, 0, 0)}, p, "case #%d", i)
+	}
+
+	// JSON keeps the object form
+	b, err := json.Marshal(struct {
+		P Period `json:"p"`
+	}{P: tbl[0].expected})
+	require.NoError(t, err)
+	assert.Equal(t, `{"p":{"start":"09:00:00.000000","end":"17:00:00.000000"}}`, string(b))
+	var m map[Period]int
+	require.NoError(t, json.Unmarshal([]byte(`{"09:00:00-17:00:00":1}`), &m))
+	assert.Equal(t, map[Period]int{tbl[0].expected: 1}, m)
+}
+
+func TestWeeklyHours_IsOpen(t *testing.T) {
+	business := Period{Start: NewUTCClock(9, 0, 0, 0), End: NewUTCClock(17, 0, 0, 0)}
+	w := WeeklyHours{
+		time.Monday:    business,
+		time.Tuesday:   business,
+		time.Wednesday: business,
+		time.Thursday:  business,
+		time.Friday:    {Start: NewUTCClock(22, 0, 0, 0), End: NewUTCClock(2, 0, 0, 0)},
+	}
+
+	// 2023-05-01 is Monday
+	at := func(day, h, m int) time.Time { return time.Date(2023, time.May, day, h, m, 0, 0, time.UTC) }
+	tbl := []struct {
+		arg      time.Time
+		expected bool
+	}{
+		{arg: at(1, 12, 0), expected: true},
+		{arg: at(1, 9, 0), expected: true},
+		{arg: at(1, 8, 59), expected: false},
+		{arg: at(1, 16, 59), expected: true},
+		{arg: at(1, 17, 0), expected: false},
+		{arg: at(3, 12, 0), expected: true},
+		{arg: at(5, 12, 0), expected: false},
+		{arg: at(5, 1, 0), expected: false},
+		{arg: at(5, 22, 0), expected: true},
+		{arg: at(5, 23, 59), expected: true},
+		{arg: at(6, 0, 0), expected: true},
+		{arg: at(6, 1, 59), expected: true},
+		{arg: at(6, 2, 0), expected: false},
+		{arg: at(6, 23, 0), expected: false},
+		{arg: at(7, 12, 0), expected: false},
+		{arg: time.Date(2023, time.May, 1, 20, 0, 0, 0, time.FixedZone("", 8*60*60)), expected: false},
+		{arg: time.Date(2023, time.May, 1, 20, 0, 0, 0, time.FixedZone("", -8*60*60)), expected: false},
+		{arg: time.Date(2023, time.May, 1, 10, 0, 0, 0, time.FixedZone("", -8*60*60)), expected: true},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, w.IsOpen(tt.arg), "case #%d", i)
+	}
+
+	assert.False(t, WeeklyHours(nil).IsOpen(at(1, 12, 0)))
+
+	// wrapping period of Saturday continues into Sunday
+	w = WeeklyHours{time.Saturday: {Start: NewUTCClock(20, 0, 0, 0), End: NewUTCClock(3, 0, 0, 0)}}
+	assert.True(t, w.IsOpen(at(7, 2, 0)))
+	assert.False(t, w.IsOpen(at(1, 2, 0)))
+}
+
+func TestWeeklyHours_JSON(t *testing.T) {
+	w := WeeklyHours{
+		time.Monday: {Start: NewUTCClock(9, 0, 0, 0), End: NewUTCClock(17, 0, 0, 0)},
+		time.Sunday: {Start: NewUTCClock(22, 0, 0, 0), End: NewUTCClock(2, 0, 0, 0)},
+	}
+	b, err := json.Marshal(w)
+	require.NoError(t, err)
+	assert.Equal(t, `{"Monday":{"start":"09:00:00.000000","end":"17:00:00.000000"},`+
+		`"Sunday":{"start":"22:00:00.000000","end":"02:00:00.000000"}}`, string(b))
+
+	var res WeeklyHours
+	require.NoError(t, json.Unmarshal(b, &res))
+	assert.Equal(t, w, res)
+
+	assert.Equal(t, ErrInvalidWeekday, json.Unmarshal([]byte(`{"Workday": {"start": "09:00:00"}}`), &res))
+	assert.Equal(t, ErrInvalidClock, json.Unmarshal([]byte(`{"Monday": {"start": 9}}`), &res))
+	assert.IsType(t, &errExternal{}, res.UnmarshalJSON([]byte(`[]`)))
+	assert.Equal(t, w, res)
+}
+
+func TestClocksOverlap(t *testing.T) {
+	c := func(h, m int) Clock { return NewUTCClock(h, m, 0, 0) }
+	tbl := []struct {
+		aStart, aEnd, bStart, bEnd Clock
+		expected                   bool
+	}{
+		{aStart: c(9, 0), aEnd: c(12, 0), bStart: c(13, 0), bEnd: c(14, 0), expected: false},
+		{aStart: c(9, 0), aEnd: c(12, 0), bStart: c(12, 0), bEnd: c(14, 0), expected: false},
+		{aStart: c(9, 0), aEnd: c(12, 0), bStart: c(11, 59), bEnd: c(14, 0), expected: true},
+		{aStart: c(9, 0), aEnd: c(12, 0), bStart: c(10, 0), bEnd: c(11, 0), expected: true},
+		{aStart: c(9, 0), aEnd: c(12, 0), bStart: c(9, 0), bEnd: c(12, 0), expected: true},
+		{aStart: c(22, 0), aEnd: c(2, 0), bStart: c(1, 0), bEnd: c(3, 0), expected: true},
+		{aStart: c(22, 0), aEnd: c(2, 0), bStart: c(2, 0), bEnd: c(22, 0), expected: false},
+		{aStart: c(22, 0), aEnd: c(2, 0), bStart: c(23, 0), bEnd: c(23, 30), expected: true},
+		{aStart: c(22, 0), aEnd: c(2, 0), bStart: c(21, 0), bEnd: c(1, 0), expected: true},
+		{aStart: c(9, 0), aEnd: c(9, 0), bStart: c(0, 0), bEnd: c(23, 0), expected: false},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, ClocksOverlap(tt.aStart, tt.aEnd, tt.bStart, tt.bEnd), "case #%d", i)
+		assert.Equal(t, tt.expected, ClocksOverlap(tt.bStart, tt.bEnd, tt.aStart, tt.aEnd), "case #%d", i)
+	}
+}
@@ -1,10 +1,15 @@
 package timetype
 
 import (
+	"bytes"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -12,21 +17,122 @@ import (
 var (
 	ErrInvalidClock    = errors.New("timetype: invalid clock")
 	ErrInvalidDuration = errors.New("timetype: invalid duration")
+	ErrInvalidDate     = errors.New("timetype: invalid date")
 )
 
-// Templates to parse clocks
+// ErrPrecisionLoss is returned in the strict precision mode, if the clock
+// can't be marshaled in the default layout without losing its precision
+var ErrPrecisionLoss = errors.New("timetype: clock precision loss")
+
+// Templates to parse clocks, that can be also passed to Clock.Format
 const (
-	ISO8601Clock      = "15:04:05"
-	ISO8601ClockMicro = "15:04:05.000000"
+	ISO8601Clock           = "15:04:05"
+	ISO8601ClockMicro      = "15:04:05.000000"
+	ISO8601ClockNano       = "15:04:05.000000000"
+	ISO8601ClockZone       = "15:04:05Z07:00"
+	ISO8601ClockMicroZone  = "15:04:05.000000Z07:00"
+	TwelveHourClock        = "3:04 PM"
+	TwelveHourClockSeconds = "3:04:05 PM"
+	TimetzClock            = "15:04:05-07"
+	TimetzClockMicro       = "15:04:05.000000-07:00"
+	ISO8601ClockShort      = "15:04"
 )
 
+// clockLayouts are the layouts tried in order to parse a clock
+var clockLayouts = []string{
+	ISO8601Clock,
+	ISO8601ClockMicro,
+	ISO8601ClockNano,
+	ISO8601ClockZone,
+	ISO8601ClockMicroZone,
+	TwelveHourClock,
+	TwelveHourClockSeconds,
+	TimetzClock,
+	TimetzClockMicro,
+	ISO8601ClockShort,
+}
+
+// RegisterClockLayout appends the layout to the list of layouts tried to parse clocks
+// by ParseClock, UnmarshalJSON, Scan and others, the layout is tried after the built-in
+// ones. Layouts, that are already in the list, are ignored. As SetDefaultClockLayout,
+// it's not safe for concurrent use, so it should be done once at the program start.
+func RegisterClockLayout(layout string) {
+	for _, l := range clockLayouts {
+		if l == layout {
+			return
+		}
+	}
+	clockLayouts = append(clockLayouts, layout)
+}
+
+// ClockLayouts returns the copy of the list of layouts tried to parse clocks, in order
+func ClockLayouts() []string {
+	return append([]string(nil), clockLayouts...)
+}
+
+// defaultClockLayout is the layout used to marshal clocks
+var defaultClockLayout = ISO8601ClockMicro
+
+// SetDefaultClockLayout sets the layout used by Clock to marshal its value into JSON,
+// text and SQL, e.g. ISO8601Clock to drop fractions of the second. Parsing of clocks
+// is not affected. It's not safe to call this function concurrently with marshaling,
+// so it should be done once at the program start.
+func SetDefaultClockLayout(layout string) {
+	defaultClockLayout = layout
+}
+
+// DefaultClockLayout returns the layout used by Clock to marshal its value into JSON,
+// text and SQL, ISO8601ClockMicro unless it's changed with SetDefaultClockLayout
+func DefaultClockLayout() string {
+	return defaultClockLayout
+}
+
+// nullZeroClock defines whether the zero Clock is marshaled into JSON null
+var nullZeroClock = false
+
+// SetNullZeroClock sets whether Clock.MarshalJSON marshals the zero clock, as left
+// by scanning SQL NULL, into JSON null instead of "00:00:00.000000". Clocks made with
+// the constructors of the package are never zero, even at midnight. As with
+// SetDefaultClockLayout, it should be called once at the program start.
+func SetNullZeroClock(enabled bool) {
+	nullZeroClock = enabled
+}
+
+// strictClockPrecision defines whether marshaling of the clock fails on the precision loss
+var strictClockPrecision = false
+
+// SetStrictClockPrecision sets whether the marshalers of Clock and AMPMClock return
+// ErrPrecisionLoss instead of silently truncating the clock, if it has more precision,
+// than their layout keeps, e.g. nanoseconds for ISO8601ClockMicro. It covers JSON, text,
+// YAML, XML, SQL values and MarshalGQLContext, but not MarshalGQL, that can't report
+// errors, and not MarshalBinary, that keeps the clock exactly. As with
+// SetDefaultClockLayout, it should be called once at the program start.
+func SetStrictClockPrecision(enabled bool) {
+	strictClockPrecision = enabled
+}
+
 // Clock is a wrapper for time.time to allow parsing datetime stamp with time only in
 // ISO 8601 format, like "15:04:05"
 type Clock time.Time
 
-// NewClock returns the Clock in the given location with given hours, minutes and secs
+// NewClock returns the Clock in the given location with given hours, minutes and secs.
+// Unlike time.Date, it doesn't normalize values out of their ranges and panics instead,
+// use NewClockChecked to handle such values gracefully.
 func NewClock(h, m, s, ns int, loc *time.Location) Clock {
-	return Clock(time.Date(0, time.January, 1, h, m, s, ns, loc))
+	c, err := NewClockChecked(h, m, s, ns, loc)
+	if err != nil {
+		panic(fmt.Sprintf("timetype: invalid clock %02d:%02d:%02d.%09d", h, m, s, ns))
+	}
+	return c
+}
+
+// NewClockChecked returns the Clock in the given location with given hours, minutes,
+// secs and nanoseconds or ErrInvalidClock if any of the values is out of its range.
+func NewClockChecked(h, m, s, ns int, loc *time.Location) (Clock, error) {
+	if h < 0 || h > 23 || m < 0 || m > 59 || s < 0 || s > 59 || ns < 0 || ns > 999999999 {
+		return Clock{}, ErrInvalidClock
+	}
+	return Clock(time.Date(0, time.January, 1, h, m, s, ns, loc)), nil
 }
 
 // NewUTCClock returns new clock with given hours, minutes and seconds in the UTC location
@@ -34,48 +140,555 @@ func NewUTCClock(h, m, s, ns int) Clock {
 	return NewClock(h, m, s, ns, time.UTC)
 }
 
+// ClockFromDuration returns the clock in the given location at the given offset from
+// midnight, wrapping it into a single day, e.g. 90m is 01:30, 25h is 01:00 and -30m is 23:30
+func ClockFromDuration(d time.Duration, loc *time.Location) Clock {
+	return clockAt((d%day+day)%day, loc)
+}
+
+// now is used to get the current time, replaced in tests
+var now = time.Now
+
+// Now returns the current time of the day in the given location
+func Now(loc *time.Location) Clock {
+	return clockOf(now().In(loc))
+}
+
+// NowUTC returns the current time of the day in the UTC location
+func NowUTC() Clock {
+	return Now(time.UTC)
+}
+
+// day is the length of the day in the Clock arithmetic
+const day = 24 * time.Hour
+
+// Add returns the clock shifted by the given duration, wrapping around midnight,
+// e.g. 23:30 + 1h results in 00:30. The location of the clock is always preserved,
+// use AddIn to get the result in another location.
+func (h Clock) Add(d time.Duration) Clock {
+	off := (h.sinceMidnight() + d%day + day) % day
+	return clockAt(off, time.Time(h).Location())
+}
+
+// AddIn returns the clock shifted by the given duration, as Add does, and converted
+// to the given location, as In does, e.g. 23:00 UTC + 2h in UTC+3 is 04:00 UTC+3.
+func (h Clock) AddIn(d time.Duration, loc *time.Location) Clock {
+	return h.Add(d).In(loc)
+}
+
+// AddWithDays returns the clock shifted by the given duration, as Add does, and the
+// signed amount of midnights crossed, positive if the clock moved past midnight forward
+// and negative if moved backward, e.g. 23:30 + 3h is 02:30 and 1, 00:30 - 1h is 23:30
+// and -1 and 10:00 + 50h is 12:00 and 2.
+func (h Clock) AddWithDays(d time.Duration) (Clock, int) {
+	days, off := int(d/day), h.sinceMidnight()+d%day
+	switch {
+	case off >= day:
+		off -= day
+		days++
+	case off < 0:
+		off += day
+		days--
+	}
+	return clockAt(off, time.Time(h).Location()), days
+}
+
+// Sub returns the signed difference between two clocks, treating both of them as
+// offsets from midnight, so the result always lies in the (-24h, 24h) range and
+// no day wraps may occur. Locations of clocks are not taken into account.
+func (h Clock) Sub(other Clock) time.Duration {
+	return h.sinceMidnight() - other.sinceMidnight()
+}
+
+// Minus returns the signed difference between two clocks, as Sub does, e.g. 09:00 minus
+// 17:00 is -8h. Unlike DiffAcross, it never wraps past midnight, so it's meant for clocks
+// known to be within the same day.
+func (h Clock) Minus(other Clock) time.Duration {
+	return h.Sub(other)
+}
+
+// On returns the time at the given date in the given location with the time of the
+// day of the clock. The wall clock of the clock is kept as is, without conversion
+// into the given location, and, if loc is nil, the location of the clock is used.
+// As with time.Date, if the clock falls into a gap or an overlap of a zone transition,
+// the result is correct in one of the two zones involved, but it's not guaranteed which.
+func (h Clock) On(year int, month time.Month, day int, loc *time.Location) time.Time {
+	t := time.Time(h)
+	if loc == nil {
+		loc = t.Location()
+	}
+	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// OnDate returns the time at the date of t in the location of t
+// with the time of the day of the clock
+func (h Clock) OnDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return h.On(year, month, day, t.Location())
+}
+
+// NextOccurrence returns the first time strictly after the given one with the time
+// of the day of the clock, either at the date of after or at the next day, if the clock
+// has already passed, e.g. to use with context.WithDeadline for "until 17:00". The date
+// is taken in the location of after and the wall clock of the clock is kept as is, as
+// OnDate does.
+func (h Clock) NextOccurrence(after time.Time) time.Time {
+	year, month, day := after.Date()
+	if t := h.On(year, month, day, after.Location()); t.After(after) {
+		return t
+	}
+	return h.On(year, month, day+1, after.Location())
+}
+
+// DiffAcross returns the smallest non-negative duration to move forward from the clock
+// to the other one, e.g. from 23:00 to 01:00 it is 2h, from 01:00 to 23:00 it is 22h,
+// and for equal clocks it is 0. The second value is the number of extra day wraps on top
+// of the duration, as the duration is the smallest forward delta, it is always 0 and
+// carries no information. Use other.Before(h) to tell whether the other clock comes
+// on the next day. Locations are ignored, as in Sub.
+func (h Clock) DiffAcross(other Clock) (time.Duration, int) {
+	d := other.Sub(h)
+	if d < 0 {
+		d += day
+	}
+	return d, 0
+}
+
+// Truncate returns the clock rounded down toward midnight to a multiple of d, counting
+// from midnight and keeping the location, e.g. 13:24:32 truncated to 15 minutes is
+// 13:15:00. If d doesn't divide the day evenly, the last interval before the midnight
+// is shorter than d, if d is 24h or longer, the result is always midnight.
+// If d <= 0, the clock is returned unchanged.
+func (h Clock) Truncate(d time.Duration) Clock {
+	if d <= 0 {
+		return h
+	}
+	off := h.sinceMidnight()
+	return clockAt(off-off%d, time.Time(h).Location())
+}
+
+// Clamp returns min if the clock is before min, max if the clock is after max and the
+// clock itself otherwise, comparing them by the time of the day, as Before does, e.g.
+// 08:00 clamped into 09:00-17:00 is 09:00. If min is after max, the window wraps past
+// midnight, e.g. 22:00-02:00, and the clock out of it is snapped to the closest bound.
+func (h Clock) Clamp(min, max Clock) Clock {
+	if !min.After(max) {
+		switch {
+		case h.Before(min):
+			return min
+		case h.After(max):
+			return max
+		}
+		return h
+	}
+
+	if !h.After(max) || !h.Before(min) {
+		return h
+	}
+	if h.Sub(max) <= min.Sub(h) {
+		return max
+	}
+	return min
+}
+
+// Before reports whether the clock is before the other one. Clocks are compared
+// by their hours, minutes, seconds and nanoseconds only, locations are ignored,
+// so 10:00 in UTC is before 12:00 in UTC+3, even though it's a later instant,
+// as 12:00 in UTC+3 is 09:00 in UTC.
+func (h Clock) Before(other Clock) bool {
+	return h.sinceMidnight() < other.sinceMidnight()
+}
+
+// After reports whether the clock is after the other one. As in Before, locations
+// of the clocks are ignored.
+func (h Clock) After(other Clock) bool {
+	return h.sinceMidnight() > other.sinceMidnight()
+}
+
+// Compare returns -1 if the clock is before the other one, +1 if it's after
+// and 0 otherwise, it's meant for sorting and three-way comparisons. As in
+// Before, locations of the clocks are ignored, so 0 doesn't imply Equal.
+func (h Clock) Compare(other Clock) int {
+	switch a, b := h.sinceMidnight(), other.sinceMidnight(); {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Between reports whether the clock is inside the range of the time of the day from
+// start to end, incStart and incEnd choose whether the bounds are included. If end is
+// before start, the range wraps past midnight, e.g. 22:00-06:00 contains 23:00 and 05:00.
+// Equal bounds contain only that clock, if both are included. As in Before, locations
+// of the clocks are ignored.
+func (h Clock) Between(start, end Clock, incStart, incEnd bool) bool {
+	from, to, off := start.sinceMidnight(), end.sinceMidnight(), h.sinceMidnight()
+	afterStart := off > from || (incStart && off == from)
+	beforeEnd := off < to || (incEnd && off == to)
+	if from <= to {
+		return afterStart && beforeEnd
+	}
+	return afterStart || beforeEnd
+}
+
+// Equal reports whether both clocks represent the same instant at the zero date,
+// i.e. the clocks are brought to the same location before the comparison, so
+// 12:00 UTC equals 15:00 UTC+3. Note that two clocks in different locations
+// might be equal, while one of them is before the other one.
+func (h Clock) Equal(other Clock) bool {
+	return time.Time(h).Equal(time.Time(other))
+}
+
+// Normalize returns the clock with the same wall clock in UTC at the zero date, e.g.
+// 19:24 in any location becomes 19:24 UTC, the instant of the clock is not preserved.
+// Normalized clocks with the same time of the day are equal with == and reflect.DeepEqual,
+// which otherwise compare locations and dates, so prefer Equal or Before and After
+// for comparison in other cases. The zero Clock stays zero.
+func (h Clock) Normalize() Clock {
+	if h.IsZero() {
+		return h
+	}
+	return clockAt(h.sinceMidnight(), time.UTC)
+}
+
+// In returns the clock converted to the given location. The conversion is made at the
+// zero date, where no daylight saving time is applied, so the offset of the location
+// at the zero date is used. Note that for the locations from the IANA database this
+// offset usually is the local mean time, so the conversion is precise only for the
+// fixed zones.
+func (h Clock) In(loc *time.Location) Clock {
+	return clockOf(time.Time(h).In(loc))
+}
+
+// UTC returns the clock converted to the UTC location
+func (h Clock) UTC() Clock {
+	return h.In(time.UTC)
+}
+
+// Key returns the canonical comparable key of the clock, the amount of nanoseconds since
+// midnight of the clock converted to UTC, as UTC does, e.g. to use clocks as keys of maps
+// or in sets, which otherwise compare locations and dates. Clocks at the same instant, like
+// 12:00 UTC and 15:00 UTC+3, have the same key. The end of the day has the key of midnight.
+func (h Clock) Key() int64 {
+	return int64(h.UTC().sinceMidnight())
+}
+
+// IsZero reports whether the clock is the zero value, as left by scanning SQL NULL.
+// Clocks made by the constructors of the package are never zero, even at midnight,
+// but a clock converted from the zero time.Time, e.g. the 00:00:00 scanned from
+// a time.Time value at January 1, year 1, UTC, is indistinguishable from the unset
+// one. Use NullClock to tell whether the value is actually set.
+func (h Clock) IsZero() bool {
+	return time.Time(h).IsZero()
+}
+
+// EndOfDay returns the clock at the end of the day in the given location, that is
+// formatted as 24:00:00 and is after any other clock, e.g. to set the closing time.
+// As the instant it's the midnight of the next day after the zero date.
+func EndOfDay(loc *time.Location) Clock {
+	return clockAt(day, loc)
+}
+
+// IsEndOfDay reports whether the clock is the end of the day, 24:00:00, as parsed
+// from "24:00:00" or returned by EndOfDay. Hour, Minute and other accessors of such
+// a clock return zeros, as for the midnight.
+func (h Clock) IsEndOfDay() bool {
+	t := time.Time(h)
+	if t.Nanosecond() != 0 {
+		return false
+	}
+	year, month, d := t.Date()
+	hour, minute, sec := t.Clock()
+	return year == 0 && month == time.January && d == 2 && hour == 0 && minute == 0 && sec == 0
+}
+
+// Hour returns the hour of the clock, in the range [0, 23]
+func (h Clock) Hour() int { return time.Time(h).Hour() }
+
+// Minute returns the minute of the clock, in the range [0, 59]
+func (h Clock) Minute() int { return time.Time(h).Minute() }
+
+// Second returns the second of the clock, in the range [0, 59]
+func (h Clock) Second() int { return time.Time(h).Second() }
+
+// Nanosecond returns the nanoseconds of the clock within the second, in the range [0, 999999999]
+func (h Clock) Nanosecond() int { return time.Time(h).Nanosecond() }
+
+// Location returns the location of the clock
+func (h Clock) Location() *time.Location { return time.Time(h).Location() }
+
+// sinceMidnight returns the wall clock offset of the clock from midnight,
+// it is 24h for the end of the day
+func (h Clock) sinceMidnight() time.Duration {
+	t := time.Time(h)
+	hour, minute, sec := t.Clock()
+	off := time.Duration(hour)*time.Hour +
+		time.Duration(minute)*time.Minute +
+		time.Duration(sec)*time.Second +
+		time.Duration(t.Nanosecond())
+	if off == 0 && h.IsEndOfDay() { // only midnights need the date to tell the end of the day
+		return day
+	}
+	return off
+}
+
+// clockOf returns the clock with the time of the given time.Time at the zero date
+func clockOf(t time.Time) Clock {
+	return Clock(time.Date(0, time.January, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()))
+}
+
+// clockAt returns the clock in the given location at the given offset from midnight,
+// the offset must be in the [0, 24h] range, 24h results in the end of the day
+func clockAt(off time.Duration, loc *time.Location) Clock {
+	return Clock(time.Date(0, time.January, 1, 0, 0, 0, int(off), loc))
+}
+
 // MarshalJSON marshals time into time
 func (h Clock) MarshalJSON() ([]byte, error) {
-	res, err := json.Marshal(time.Time(h).Format(ISO8601ClockMicro))
-	return res, wrapExternalErr(err)
+	if nullZeroClock && h.IsZero() {
+		return []byte("null"), nil
+	}
+	if err := h.checkPrecision(); err != nil {
+		return nil, err
+	}
+	res, err := json.Marshal(h.Format(defaultClockLayout))
+	return res, wrapExternalErr("marshal", "clock", err)
+}
+
+// checkPrecision returns ErrPrecisionLoss in the strict precision mode, if the clock
+// formatted in the default layout doesn't parse back to the same time of the day
+func (h Clock) checkPrecision() error {
+	return h.checkPrecisionIn(defaultClockLayout)
+}
+
+// checkPrecisionIn returns ErrPrecisionLoss in the strict precision mode, if the clock
+// formatted in the given layout doesn't parse back to the same time of the day
+func (h Clock) checkPrecisionIn(layout string) error {
+	if !strictClockPrecision || h.IsEndOfDay() {
+		return nil
+	}
+	t, err := time.Parse(layout, h.Format(layout))
+	if err != nil || Clock(t).sinceMidnight() != h.sinceMidnight() {
+		return ErrPrecisionLoss
+	}
+	return nil
+}
+
+// Format returns the clock formatted according to the layout, as time.Time.Format does,
+// e.g. "7:24 PM" for TwelveHourClock. As the clock is at the zero date, only the layout
+// elements of the time of the day and the zone are meaningful. The end of the day is
+// formatted as 24:00:00, if the layout starts with the 24-hour "15".
+func (h Clock) Format(layout string) string {
+	var buf [64]byte // most layouts fit, so the buffer doesn't escape
+	return string(h.appendFormat(buf[:0], layout))
+}
+
+// AppendFormat appends the clock formatted according to the default layout, as
+// MarshalText does, to b and returns the extended buffer, as time.Time.AppendFormat does
+func (h Clock) AppendFormat(b []byte) []byte {
+	return h.appendFormat(b, defaultClockLayout)
+}
+
+func (h Clock) appendFormat(b []byte, layout string) []byte {
+	if layout == ISO8601ClockMicro { // the default layout, used to marshal clocks in bulk
+		return appendClockMicro(b, h.sinceMidnight())
+	}
+	if h.IsEndOfDay() && strings.HasPrefix(layout, "15") {
+		return time.Time(h).AppendFormat(append(b, "24"...), layout[2:])
+	}
+	return time.Time(h).AppendFormat(b, layout)
+}
+
+// appendClockMicro appends the clock at the given offset from midnight in the
+// ISO8601ClockMicro layout, as time.Time.AppendFormat does, but without parsing
+// the layout, fractions of the microsecond are truncated
+func appendClockMicro(b []byte, off time.Duration) []byte {
+	secs, micros := int(off/time.Second), int(off%time.Second/time.Microsecond)
+	b = appendPadded(b, secs/3600, 2)
+	b = appendPadded(append(b, ':'), secs/60%60, 2)
+	b = appendPadded(append(b, ':'), secs%60, 2)
+	return appendPadded(append(b, '.'), micros, 6)
+}
+
+// appendPadded appends the non-negative number, zero-padded to the given width
+func appendPadded(b []byte, v, width int) []byte {
+	var buf [8]byte
+	i := len(buf)
+	for ; width > 0 || v > 0; width-- {
+		i--
+		buf[i] = byte('0' + v%10)
+		v /= 10
+	}
+	return append(b, buf[i:]...)
 }
 
 // String implements fmt.Stringer to print and log Clock properly
 func (h Clock) String() string {
 	t := time.Time(h)
-	return fmt.Sprintf("%02d:%02d:%02d %s", t.Hour(), t.Minute(), t.Second(), t.Location())
+	hour := t.Hour()
+	if h.IsEndOfDay() {
+		hour = 24
+	}
+	return fmt.Sprintf("%02d:%02d:%02d %s", hour, t.Minute(), t.Second(), t.Location())
+}
+
+// ShortString returns the clock as String does, but without the location and
+// sub-second parts, e.g. "17:54:00", for logs and UIs where the zone is implied
+func (h Clock) ShortString() string {
+	return h.Format(ISO8601Clock)
 }
 
 // GoString implements fmt.GoStringer to use Clock in %#v formats
 func (h Clock) GoString() string {
 	t := time.Time(h)
+	if h.IsEndOfDay() {
+		return fmt.Sprintf("timetype.EndOfDay(%s)", t.Location())
+	}
 	return fmt.Sprintf("timetype.NewClock(%d, %d, %d, %s)", t.Hour(), t.Minute(), t.Second(), t.Location())
 }
 
-// UnmarshalJSON converts time to ISO 8601 representation
+// UnmarshalJSON converts time to ISO 8601 representation.
+// JSON null leaves the clock unchanged.
 func (h *Clock) UnmarshalJSON(b []byte) error {
 	var v interface{}
 	if err := json.Unmarshal(b, &v); err != nil {
-		return wrapExternalErr(err)
+		return wrapExternalErr("unmarshal", "clock", err)
+	}
+	if v == nil { // null is a no-op, as in encoding/json
+		return nil
 	}
 	val, ok := v.(string)
 	if !ok {
 		return ErrInvalidClock
 	}
-	t, err := TryParseTime(val, ISO8601Clock, ISO8601ClockMicro)
+	c, err := ParseClock(val)
+	if err != nil {
+		return err
+	}
+	*h = c
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and marshals the clock
+// in the same format as MarshalJSON does, but without quotes. It makes Clock
+// usable as a key of JSON objects. Keys are wall clocks, as Normalize keeps them:
+// the default layout has no zone, so clocks with the same wall clock in different
+// locations, like 09:00 UTC and 09:00 in America/New_York, result in the same key
+// and are parsed back in UTC. Use a layout with the zone offset to keep it in keys.
+func (h Clock) MarshalText() ([]byte, error) {
+	if err := h.checkPrecision(); err != nil {
+		return nil, err
+	}
+	return []byte(h.Format(defaultClockLayout)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and parses the clock
+// in any of the known layouts
+func (h *Clock) UnmarshalText(b []byte) error {
+	c, err := ParseClock(string(b))
 	if err != nil {
 		return err
 	}
-	*h = Clock(t)
+	*h = c
 	return nil
 }
 
+// ParseClock parses the clock in any of the known layouts, as UnmarshalJSON does.
+// Clocks without the zone offset are parsed in UTC. UnknownFormatError is returned
+// if the value matches none of the layouts. The hour 24 is allowed only for the end
+// of the day in the 24-hour layouts, "24:00:00", see IsEndOfDay, ErrInvalidClock is
+// returned for other clocks with such hour, like "24:00:01", and 12-hour clocks, like
+// "24:00 PM", are not parsed. Surrounding whitespace, like " 19:24:00 ", is ignored.
+func ParseClock(val string) (Clock, error) {
+	return parseClock(val, time.Parse)
+}
+
+// ParseClockInLocation parses the clock as ParseClock does, but clocks without
+// the zone offset are parsed in the given location, as time.ParseInLocation does.
+func ParseClockInLocation(val string, loc *time.Location) (Clock, error) {
+	return parseClock(val, func(layout, val string) (time.Time, error) {
+		return time.ParseInLocation(layout, val, loc)
+	})
+}
+
+// ParseClocks parses the list of clocks separated by sep, like "09:00:00,12:30:00",
+// each element is parsed as ParseClock does. Errors of all malformed elements are
+// joined and mention the position of the element. The empty value results in the
+// empty list.
+func ParseClocks(value, sep string) ([]Clock, error) {
+	if strings.TrimSpace(value) == "" {
+		return []Clock{}, nil
+	}
+	parts := strings.Split(value, sep)
+	res := make([]Clock, 0, len(parts))
+	var errs []error
+	for i, part := range parts {
+		c, err := ParseClock(part)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("clock #%d: %w", i, err))
+			continue
+		}
+		res = append(res, c)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return res, nil
+}
+
+// parseClock parses the clock with parse in any of the known layouts,
+// handling the end of the day, surrounding whitespace is ignored
+func parseClock(val string, parse func(layout, val string) (time.Time, error)) (Clock, error) {
+	val = strings.TrimSpace(val)
+	layouts := clockLayouts
+	endOfDay := strings.HasPrefix(val, "24")
+	if endOfDay { // time.Parse doesn't accept the hour 24
+		val = "00" + val[2:]
+		layouts = dayEndLayouts()
+	}
+	t, err := tryParse(val, parse, layouts)
+	if err != nil {
+		if ue, ok := err.(*UnknownFormatError); ok && endOfDay {
+			ue.Val = "24" + val[2:]
+		}
+		return Clock{}, err
+	}
+	if !endOfDay {
+		return Clock(t), nil
+	}
+	if c := Clock(t); c.sinceMidnight() != 0 {
+		return Clock{}, ErrInvalidClock
+	}
+	return Clock(t.AddDate(0, 0, 1)), nil
+}
+
+// dayEndLayouts returns the known layouts with the 24-hour clock, the only ones,
+// where the end of the day may be written, e.g. "24:00 PM" is not a clock
+func dayEndLayouts() []string {
+	res := make([]string, 0, len(clockLayouts))
+	for _, l := range clockLayouts {
+		if strings.HasPrefix(l, "15") {
+			res = append(res, l)
+		}
+	}
+	return res
+}
+
 // TryParseTime tries to parse the value as a time.Time in several
 // formats, it doesn't
 func TryParseTime(val string, formats ...string) (time.Time, error) {
+	return tryParse(val, time.Parse, formats)
+}
+
+// tryParse parses the value with parse in each of the formats and returns the first
+// successfully parsed time or UnknownFormatError with all errors got from parse
+func tryParse(val string, parse func(layout, val string) (time.Time, error), formats []string) (time.Time, error) {
 	ue := UnknownFormatError{Layouts: formats, Val: val}
 	for _, fm := range formats {
-		t, err := time.Parse(fm, val)
+		t, err := parse(fm, val)
 		if err == nil {
 			return t, nil
 		}
@@ -104,29 +717,68 @@ func (e *UnknownFormatError) Error() string {
 	return fmt.Sprintf("timetype: failed to parse "+quote(e.Val)+" in layouts: [%s]", lts)
 }
 
+// Unwrap returns errors got from all attempts to parse the value,
+// so they can be inspected with errors.Is and errors.As
+func (e *UnknownFormatError) Unwrap() []error {
+	return e.Errors
+}
+
 func quote(s string) string {
 	return "\"" + s + "\""
 }
 
-// Scan the given SQL value as Clock
+// Scan the given SQL value as Clock. Integers are considered to be the amount of
+// seconds since midnight in the [0, 86399] range, and are scanned as UTC clocks, as
+// well as floats, which are the fractional amount of seconds in the [0, 86400) range.
+// Values of time.Time are moved to the zero date, keeping their wall clock and zone
+// offset, as scanClockOf does, strings are parsed as ParseClock does, so they're in UTC
+// unless they have the zone offset.
 func (h *Clock) Scan(src interface{}) (err error) {
 	switch v := src.(type) {
 	case nil:
 		*h = Clock{}
 	case time.Time:
-		*h = Clock(v)
+		*h = scanClockOf(v)
+	case *time.Time:
+		*h = Clock{}
+		if v != nil {
+			*h = scanClockOf(*v)
+		}
+	case sql.NullTime:
+		*h = Clock{}
+		if v.Valid {
+			*h = scanClockOf(v.Time)
+		}
 	case string:
-		t, err := TryParseTime(v, ISO8601Clock, ISO8601ClockMicro)
+		c, err := ParseClock(v)
 		if err != nil {
 			return err
 		}
-		*h = Clock(t)
+		*h = c
 	case []byte:
-		t, err := TryParseTime(string(v), ISO8601Clock, ISO8601ClockMicro)
+		c, err := ParseClock(string(v))
+		if err != nil {
+			return err
+		}
+		*h = c
+	case int64:
+		c, err := clockFromSeconds(v)
+		if err != nil {
+			return err
+		}
+		*h = c
+	case int:
+		c, err := clockFromSeconds(int64(v))
+		if err != nil {
+			return err
+		}
+		*h = c
+	case float64:
+		c, err := clockFromFloatSeconds(v)
 		if err != nil {
 			return err
 		}
-		*h = Clock(t)
+		*h = c
 	default:
 		return ErrInvalidClock
 	}
@@ -134,42 +786,575 @@ func (h *Clock) Scan(src interface{}) (err error) {
 	return err
 }
 
+// scanClockOf returns the clock with the time of the given time.Time at the zero date,
+// as clockOf does, so it's the same instant of the day, e.g. 19:24+02:00 stays 19:24+02:00.
+// Locations other than UTC are replaced with the fixed zone of the offset at the date of t,
+// as named locations usually have other offsets at the zero date. The zero time.Time
+// results in the zero Clock.
+func scanClockOf(t time.Time) Clock {
+	if t.IsZero() {
+		return Clock{}
+	}
+	if t.Location() != time.UTC {
+		name, offset := t.Zone()
+		t = t.In(time.FixedZone(name, offset))
+	}
+	return clockOf(t)
+}
+
+// clockFromSeconds returns the UTC clock with the given amount of seconds since midnight
+func clockFromSeconds(secs int64) (Clock, error) {
+	if secs < 0 || secs >= int64(day/time.Second) {
+		return Clock{}, ErrInvalidClock
+	}
+	return clockAt(time.Duration(secs)*time.Second, time.UTC), nil
+}
+
+// clockFromFloatSeconds returns the UTC clock with the given fractional amount of seconds
+// since midnight, rounded to nanoseconds
+func clockFromFloatSeconds(secs float64) (Clock, error) {
+	if math.IsNaN(secs) || secs < 0 || secs >= float64(day/time.Second) {
+		return Clock{}, ErrInvalidClock
+	}
+	off := time.Duration(math.Round(secs * float64(time.Second)))
+	if off >= day {
+		return Clock{}, ErrInvalidClock
+	}
+	return clockAt(off, time.UTC), nil
+}
+
 // Value returns the SQL value of the given Clock
 func (h Clock) Value() (driver.Value, error) {
-	return time.Time(h).Format(ISO8601ClockMicro), nil
+	if err := h.checkPrecision(); err != nil {
+		return nil, err
+	}
+	return h.Format(defaultClockLayout), nil
+}
+
+// ValueWithZone returns the SQL value of the given Clock with the zone offset, like
+// "19:24:00.000000+02:00", as PostgreSQL timetz columns keep it. Scan parses such
+// values back with the offset preserved.
+func (h Clock) ValueWithZone() (driver.Value, error) {
+	if err := h.checkPrecisionIn(TimetzClockMicro); err != nil {
+		return nil, err
+	}
+	return h.Format(TimetzClockMicro), nil
+}
+
+// ValueSeconds returns the SQL value of the given Clock as the int64 amount of seconds
+// since midnight, fractions of the second are dropped, unless the strict precision
+// mode is enabled, see SetStrictClockPrecision. Scan parses such integers back
+// into the UTC clock, so the location of the clock is lost on the round trip.
+func (h Clock) ValueSeconds() (driver.Value, error) {
+	if err := h.checkPrecisionIn(ISO8601Clock); err != nil {
+		return nil, err
+	}
+	return int64(h.sinceMidnight() / time.Second), nil
+}
+
+// rejectNegativeDuration defines whether marshaling of negative durations fails
+var rejectNegativeDuration = false
+
+// SetRejectNegativeDuration sets whether Duration.MarshalJSON, MarshalText and Value,
+// and StringDuration.Value return ErrInvalidDuration for negative durations instead of
+// marshaling them as "-1h5m3s". Parsing of durations is not affected. As with
+// SetDefaultClockLayout, it should be called once at the program start.
+func SetRejectNegativeDuration(enabled bool) {
+	rejectNegativeDuration = enabled
+}
+
+// strictDurationParse defines whether ambiguous duration strings are rejected
+var strictDurationParse = false
+
+// SetStrictDurationParse sets whether ParseDuration, and so Duration.UnmarshalJSON, Scan
+// and others, reject duration strings with repeated units, like "1h1h", which are summed
+// up by time.ParseDuration, and return ErrInvalidDuration for malformed and overflowing
+// values, like "9999999h". As with SetDefaultClockLayout, it should be called once at
+// the program start.
+func SetStrictDurationParse(enabled bool) {
+	strictDurationParse = enabled
 }
 
 // Duration is a wrapper of time.Duration, that allows to marshal and unmarshal time in RFC3339 format
 type Duration time.Duration
 
-// MarshalJSON simply marshals duration into nanoseconds
+// zeroDurationString defines the representation of the zero duration
+var zeroDurationString = "0s"
+
+// SetZeroDurationString sets the representation of the zero duration, that is used by
+// Duration.String and all marshalers of the duration in the string form, e.g. "0" or
+// an empty string for APIs, that treat them as the absent value. Such representation is
+// parsed back into the zero duration. As with SetDefaultClockLayout, it should be called
+// once at the program start.
+func SetZeroDurationString(s string) {
+	zeroDurationString = s
+}
+
+// String implements fmt.Stringer and returns the duration as time.Duration does,
+// e.g. "1h5m3s", except the zero duration, that is set with SetZeroDurationString
+func (d Duration) String() string {
+	if d == 0 {
+		return zeroDurationString
+	}
+	return time.Duration(d).String()
+}
+
+// parseDurationString parses the marshaled duration, as ParseDuration does,
+// and the representation of the zero duration, set by SetZeroDurationString
+func parseDurationString(s string) (Duration, error) {
+	if s == zeroDurationString {
+		return 0, nil
+	}
+	return ParseDuration(s)
+}
+
+// parseDurationBytes parses the marshaled duration, as parseDurationString does.
+// Bare integers and durations with integer amounts of units, like "1h5m3s", that
+// are the most common, are parsed without allocations, convert the other ones.
+func parseDurationBytes(b []byte) (Duration, error) {
+	s := string(b) // doesn't escape, so short values are converted without allocations
+	if d, ok := parseIntegerUnits(s); ok {
+		return d, nil
+	}
+	return parseDurationString(strings.Clone(s))
+}
+
+// parseIntegerUnits parses the duration, as parseDurationString does, if it's either the
+// bare integer or consists of integer amounts of units with the fixed length, like "1h5m3s",
+// and reports whether it succeeded. It parses the value in place, so the value doesn't
+// escape, and leaves the other values and errors to parseDurationString.
+func parseIntegerUnits(s string) (Duration, bool) {
+	switch {
+	case s == zeroDurationString:
+		return 0, true
+	case strictDurationParse: // units may not repeat, let the strict parser check them
+		return 0, false
+	case isInteger(s):
+		ns, err := strconv.ParseInt(s, 10, 64)
+		return Duration(ns), err == nil
+	}
+
+	neg, rest := cutSign(s)
+	if rest == "" {
+		return 0, false
+	}
+	var total uint64
+	for rest != "" {
+		num, symbol, tail := cutComponent(rest)
+		rest = tail
+		u, ok := parseUnitSymbol(symbol)
+		if !ok || !isDigits(num) {
+			return 0, false
+		}
+		length, err := u.Duration()
+		if err != nil { // ambiguous unit
+			return 0, false
+		}
+		n, err := strconv.ParseUint(num, 10, 64)
+		if err != nil || n > (1<<63)/uint64(length) || n*uint64(length) > 1<<63-total {
+			return 0, false
+		}
+		total += n * uint64(length)
+	}
+	d, err := signedDuration(neg, total)
+	return d, err == nil
+}
+
+// DurationJSONFormat is the form, in which Duration.MarshalJSON marshals durations
+type DurationJSONFormat int
+
+// Forms of durations in JSON
+const (
+	// DurationJSONString is the duration string, like "1h5m3s"
+	DurationJSONString DurationJSONFormat = iota
+	// DurationJSONObject is the object of google.protobuf.Duration,
+	// like {"seconds":3903,"nanos":0}
+	DurationJSONObject
+	// DurationJSONISO8601 is the duration string in ISO 8601 format, like "PT1H5M3S",
+	// as Duration.ISO8601 formats it
+	DurationJSONISO8601
+)
+
+// durationJSONFormat defines the form of durations in JSON
+var durationJSONFormat = DurationJSONString
+
+// SetDurationJSONFormat sets the form, in which Duration.MarshalJSON marshals durations,
+// DurationJSONString by default. UnmarshalJSON accepts all forms regardless of this
+// setting. As with SetDefaultClockLayout, it should be called once at the program start.
+func SetDurationJSONFormat(f DurationJSONFormat) {
+	durationJSONFormat = f
+}
+
+// durationObject is the JSON object form of the duration, as google.protobuf.Duration
+// has it, nanos have the same sign as seconds
+type durationObject struct {
+	Seconds int64 `json:"seconds"`
+	Nanos   int32 `json:"nanos"`
+}
+
+// duration returns the duration of the object or ErrInvalidDuration
+// if the object is malformed or out of the range of time.Duration
+func (o durationObject) duration() (Duration, error) {
+	const nanosPerSec = int64(time.Second)
+	nanos := int64(o.Nanos)
+	switch {
+	case nanos <= -nanosPerSec || nanos >= nanosPerSec,
+		o.Seconds > 0 && nanos < 0, o.Seconds < 0 && nanos > 0,
+		o.Seconds > math.MaxInt64/nanosPerSec, o.Seconds < math.MinInt64/nanosPerSec:
+		return 0, ErrInvalidDuration
+	}
+	res := o.Seconds * nanosPerSec
+	if nanos > 0 && res > math.MaxInt64-nanos || nanos < 0 && res < math.MinInt64-nanos {
+		return 0, ErrInvalidDuration
+	}
+	return Duration(res + nanos), nil
+}
+
+// durationFromPair returns the duration of the decoded JSON array of the amount
+// and the name of the unit, like [5, "minutes"], or ErrInvalidDuration if the array
+// is malformed or the unit is unknown, the unit is parsed as ParseUnit does
+func durationFromPair(pair []interface{}) (Duration, error) {
+	if len(pair) != 2 {
+		return 0, ErrInvalidDuration
+	}
+	amount, ok := pair[0].(float64)
+	if !ok {
+		return 0, ErrInvalidDuration
+	}
+	name, ok := pair[1].(string)
+	if !ok {
+		return 0, ErrInvalidDuration
+	}
+	unit, err := ParseUnit(name)
+	if err != nil {
+		return 0, ErrInvalidDuration
+	}
+	length, err := unit.Duration()
+	if err != nil {
+		return 0, err
+	}
+	return durationFromFloat(amount * float64(length))
+}
+
+// MarshalJSON marshals the duration into the string, like "1h5m3s", or into the form
+// set with SetDurationJSONFormat
 func (d Duration) MarshalJSON() ([]byte, error) {
-	return json.Marshal(time.Duration(d).String())
+	if rejectNegativeDuration && d < 0 {
+		return nil, ErrInvalidDuration
+	}
+	switch durationJSONFormat {
+	case DurationJSONObject:
+		obj := durationObject{
+			Seconds: int64(d) / int64(time.Second),
+			Nanos:   int32(int64(d) % int64(time.Second)),
+		}
+		return json.Marshal(obj)
+	case DurationJSONISO8601:
+		return json.Marshal(d.ISO8601())
+	default:
+		return json.Marshal(d.String())
+	}
 }
 
-// UnmarshalJSON converts time duration from RFC3339 format into time.Duration
+// UnmarshalJSON converts time duration from RFC3339 format into time.Duration.
+// Strings are parsed with ParseDuration, so days and weeks, like "7d", are allowed,
+// but unitless strings, like "30", are rejected, use JSON numbers for nanoseconds.
+// Objects are decoded as google.protobuf.Duration, like {"seconds":3903,"nanos":0},
+// at least one of the fields is required and unknown fields are rejected. Arrays are
+// decoded as the amount and the name of the unit, like [5, "minutes"], where the unit
+// is one of nanoseconds, microseconds, milliseconds, seconds, minutes, hours, days
+// or weeks.
 func (d *Duration) UnmarshalJSON(b []byte) error {
 	var v interface{}
 	if err := json.Unmarshal(b, &v); err != nil {
-		return wrapExternalErr(err)
+		return wrapExternalErr("unmarshal", "duration", err)
 	}
 	switch value := v.(type) {
+	case []interface{}:
+		tmp, err := durationFromPair(value)
+		if err != nil {
+			return err
+		}
+		*d = tmp
+		return nil
+	case map[string]interface{}:
+		var obj durationObject
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&obj); err != nil {
+			return wrapExternalErr("unmarshal", "duration", err)
+		}
+		if len(value) == 0 { // neither seconds nor nanos, as unknown fields are rejected
+			return ErrInvalidDuration
+		}
+		tmp, err := obj.duration()
+		if err != nil {
+			return err
+		}
+		*d = tmp
+		return nil
 	case float64:
-		*d = Duration(time.Duration(value))
+		tmp, err := durationFromFloat(value)
+		if err != nil {
+			return err
+		}
+		*d = tmp
 		return nil
 	case string:
-		tmp, err := time.ParseDuration(value)
+		if value == zeroDurationString {
+			*d = 0
+			return nil
+		}
+		// unlike JSON numbers, unitless strings, like "30", are rather seconds
+		// than nanoseconds, so they are rejected as missing the unit
+		tmp, err := parseUnitDuration(value)
 		if err != nil {
-			return wrapExternalErr(err)
+			return withErrOp("unmarshal", err)
 		}
-		*d = Duration(tmp)
+		*d = tmp
 		return nil
 	default:
 		return ErrInvalidDuration
 	}
 }
 
-// Scan the given SQL value as Duration
+// AppendFormat appends the duration in the same format as MarshalText, e.g. "1h5m3s",
+// to b and returns the extended buffer
+func (d Duration) AppendFormat(b []byte) []byte {
+	return append(b, d.String()...)
+}
+
+// MarshalText implements encoding.TextMarshaler and marshals
+// the duration in the same format as MarshalJSON, e.g. "1h5m3s"
+func (d Duration) MarshalText() ([]byte, error) {
+	if rejectNegativeDuration && d < 0 {
+		return nil, ErrInvalidDuration
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and parses either
+// the duration string, like "1h5m3s" or "7d", or the integer amount of
+// nanoseconds, as ParseDuration does
+func (d *Duration) UnmarshalText(b []byte) error {
+	tmp, err := parseDurationBytes(b)
+	if err != nil {
+		return withErrOp("unmarshal", err)
+	}
+	*d = tmp
+	return nil
+}
+
+// ParseDuration parses the duration string in the format of time.ParseDuration, that is
+// extended with days ("d") and weeks ("w"), considering days to be exactly 24 hours long,
+// e.g. "2d" is 48h and "-1w2d3h" is -219h. Bare integers are parsed as nanoseconds and
+// values starting with "P" are parsed in ISO 8601 format, as ParseISO8601Duration does.
+// Years ("y") and months ("mo") have no fixed length, ErrAmbiguousUnit is returned for them.
+func ParseDuration(s string) (Duration, error) {
+	if isInteger(s) {
+		if ns, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return Duration(ns), nil
+		}
+		if strictDurationParse {
+			return 0, ErrInvalidDuration
+		}
+	}
+	return parseUnitDuration(s)
+}
+
+// parseUnitDuration parses the duration string with units, as ParseDuration does,
+// but without the fallback to bare integers, that are rejected as missing the unit
+func parseUnitDuration(s string) (Duration, error) {
+	switch {
+	case s == "":
+		return 0, ErrInvalidDuration
+	case isISO8601Duration(s):
+		return ParseISO8601Duration(s)
+	}
+	if strictDurationParse {
+		return parseStrictDuration(s)
+	}
+
+	if !strings.ContainsAny(s, "dwyo") { // none of the standard units has these letters
+		return parseStdDuration(s)
+	}
+
+	neg, rest := cutSign(s)
+	days, stdParts, extended, err := extendedUnits(rest)
+	switch {
+	case err != nil:
+		return 0, err
+	case !extended:
+		return parseStdDuration(s)
+	}
+
+	var std uint64
+	if stdParts != "" {
+		d, err := time.ParseDuration(stdParts)
+		if err != nil { // the error quotes only the standard units, so mention the whole value
+			return 0, wrapExternalErr("parse", "duration", fmt.Errorf("%q: %w", s, err))
+		}
+		std = uint64(d)
+	}
+	return signedDuration(neg, days+std)
+}
+
+// extendedUnits sums the days and weeks of the unsigned duration string, like "1w2d3h",
+// into the amount of nanoseconds and joins the rest of its components with the standard
+// units, like "3h". It reports whether the value has any days or weeks, the value is
+// left to the standard parser, if it hasn't or if it's malformed.
+func extendedUnits(s string) (days uint64, std string, extended bool, err error) {
+	var stdParts strings.Builder
+	for s != "" {
+		num, unit, rest := cutComponent(s)
+		s = rest
+		if num == "" { // malformed, let the standard parser report it
+			return 0, "", false, nil
+		}
+
+		u, ok := parseUnitSymbol(unit)
+		if !ok || u < Day { // the standard unit
+			stdParts.WriteString(num + unit)
+			continue
+		}
+		length, err := u.Duration()
+		if err != nil {
+			return 0, "", false, err
+		}
+		extended = true
+		hours, err := time.ParseDuration(num + "h")
+		if err != nil { // the number is either malformed or too large
+			return 0, "", false, ErrInvalidDuration
+		}
+		scale := uint64(length / time.Hour)
+		if uint64(hours) > (math.MaxInt64-days)/scale {
+			return 0, "", false, ErrInvalidDuration
+		}
+		days += uint64(hours) * scale
+	}
+	return days, stdParts.String(), extended, nil
+}
+
+// parseStrictDuration parses the duration as ParseDuration does, but each unit may occur
+// only once, and ErrInvalidDuration is returned for malformed and overflowing values
+func parseStrictDuration(s string) (Duration, error) {
+	neg, rest := cutSign(s)
+	if rest == "" {
+		return 0, ErrInvalidDuration
+	}
+
+	var total uint64
+	seen := map[string]bool{}
+	for rest != "" {
+		num, unit, tail := cutComponent(rest)
+		rest = tail
+		if num == "" || unit == "" || seen[unit] {
+			return 0, ErrInvalidDuration
+		}
+		seen[unit] = true
+
+		v, err := strictComponent(num, unit)
+		if err != nil {
+			return 0, err
+		}
+		if v > 1<<63-total {
+			return 0, ErrInvalidDuration
+		}
+		total += v
+	}
+	return signedDuration(neg, total)
+}
+
+// strictComponent returns the amount of nanoseconds in the single component of the
+// duration string, like "1.5h" or "2d", or ErrInvalidDuration for unknown units,
+// malformed and overflowing numbers
+func strictComponent(num, unit string) (uint64, error) {
+	scale, stdUnit := uint64(1), unit
+	if u, ok := parseUnitSymbol(unit); ok && u >= Day {
+		length, err := u.Duration()
+		if err != nil {
+			return 0, err
+		}
+		scale, stdUnit = uint64(length/time.Hour), "h"
+	}
+	v, err := time.ParseDuration(num + stdUnit)
+	if err != nil || uint64(v) > (1<<63)/scale {
+		return 0, ErrInvalidDuration
+	}
+	return uint64(v) * scale, nil
+}
+
+// cutSign cuts the leading sign of the duration string and reports whether it's negative
+func cutSign(s string) (neg bool, rest string) {
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		return s[0] == '-', s[1:]
+	}
+	return false, s
+}
+
+// cutComponent cuts the leading component of the duration string, that is the number
+// with the optional fractional part, like "1.5" or "1,5", and the unit after it, like
+// "h" or "H", and returns the rest of the string
+func cutComponent(s string) (num, unit, rest string) {
+	i := 0
+	for i < len(s) && (s[i] == '.' || s[i] == ',' || s[i] >= '0' && s[i] <= '9') {
+		i++
+	}
+	j := i
+	for j < len(s) && s[j] != '.' && s[j] != ',' && (s[j] < '0' || s[j] > '9') {
+		j++
+	}
+	return s[:i], s[i:j], s[j:]
+}
+
+// signedDuration returns the duration with the given sign and the absolute amount
+// of nanoseconds, or ErrInvalidDuration, if it's out of the range of Duration
+func signedDuration(neg bool, total uint64) (Duration, error) {
+	switch {
+	case neg && total == 1<<63:
+		return Duration(math.MinInt64), nil
+	case neg:
+		return Duration(-int64(total)), nil
+	case total > math.MaxInt64:
+		return 0, ErrInvalidDuration
+	}
+	return Duration(total), nil
+}
+
+// isInteger checks whether the value consists only of digits with an optional sign,
+// it allows to skip the allocating error of strconv for the duration strings
+func isInteger(s string) bool {
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+	return isDigits(s)
+}
+
+// isDigits reports whether s is not empty and consists of decimal digits only
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseStdDuration parses the duration with time.ParseDuration
+func parseStdDuration(s string) (Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, wrapExternalErr("parse", "duration", err)
+	}
+	return Duration(d), nil
+}
+
+// Scan the given SQL value as Duration. Floats are rounded to the nearest nanosecond.
+// Bytes of bare integers and integer amounts of units, like "1h5m3s", are parsed
+// without allocations.
 func (d *Duration) Scan(src interface{}) (err error) {
 	switch v := src.(type) {
 	case nil:
@@ -177,39 +1362,302 @@ func (d *Duration) Scan(src interface{}) (err error) {
 	case time.Duration:
 		*d = Duration(v)
 	case float64:
-		*d = Duration(v)
+		tmp, err := durationFromFloat(v)
+		if err != nil {
+			return err
+		}
+		*d = tmp
 	case int64:
 		*d = Duration(v)
 	case string:
-		err = wrapExternalErr(d.UnmarshalJSON([]byte(v)))
+		err = d.UnmarshalText([]byte(trimQuotes(v)))
 	case []byte:
-		err = wrapExternalErr(d.UnmarshalJSON(v))
+		if len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"' { // trim quotes without copying
+			v = v[1 : len(v)-1]
+		}
+		err = d.UnmarshalText(v)
 	default:
 		return ErrInvalidDuration
 	}
 
-	return err
+	return withErrOp("scan", err)
 }
 
-// Value returns the SQL value of the given Duration
+// durationFromFloat returns the duration of the float amount of nanoseconds, rounded
+// to the nearest nanosecond. Note that float64 represents integers exactly only up to
+// 2^53, so durations longer than ~104 days lose the nanosecond resolution anyway.
+// ErrInvalidDuration is returned for values out of the range of Duration and NaN.
+func durationFromFloat(v float64) (Duration, error) {
+	v = math.Round(v)
+	if math.IsNaN(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+		return 0, ErrInvalidDuration
+	}
+	return Duration(v), nil
+}
+
+// trimQuotes removes the surrounding double quotes from the string, if they're present
+func trimQuotes(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// Value returns the SQL value of the given Duration as the int64 amount of nanoseconds,
+// the value is emitted as is, even if the duration is not Valid
 func (d Duration) Value() (driver.Value, error) {
+	if rejectNegativeDuration && d < 0 {
+		return nil, ErrInvalidDuration
+	}
 	return int64(d), nil
 }
 
+// IsZero reports whether the duration is zero
+func (d Duration) IsZero() bool {
+	return d == 0
+}
+
+// Valid reports whether the duration is in the representable range, which is about
+// ±292 years, (math.MinInt64, math.MaxInt64) nanoseconds. The bounds are excluded, as
+// time.Time.Sub and other arithmetic saturate to them on overflow, e.g. the difference
+// between times too far apart, so such a duration is not the real one.
+func (d Duration) Valid() bool {
+	return d != math.MinInt64 && d != math.MaxInt64
+}
+
+// Days returns the duration as a floating point number of days,
+// considering the day to be exactly 24 hours long
+func (d Duration) Days() float64 {
+	days := d / Duration(day)
+	rem := d % Duration(day)
+	return float64(days) + float64(rem)/float64(day)
+}
+
+// Weeks returns the duration as a floating point number of weeks,
+// considering the week to be exactly 7 days long
+func (d Duration) Weeks() float64 {
+	weeks := d / Duration(7*day)
+	rem := d % Duration(7*day)
+	return float64(weeks) + float64(rem)/float64(7*day)
+}
+
+// WholeDays returns the amount of whole days in the duration, rounded down,
+// e.g. 1 for 36h and -2 for -36h
+func (d Duration) WholeDays() int64 {
+	return floorDiv(int64(d), int64(day))
+}
+
+// WholeWeeks returns the amount of whole weeks in the duration, rounded down,
+// e.g. 1 for 10 days and -2 for -10 days
+func (d Duration) WholeWeeks() int64 {
+	return floorDiv(int64(d), int64(7*day))
+}
+
+// floorDiv returns a / b rounded down, b must be positive
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b < 0 {
+		q--
+	}
+	return q
+}
+
+// Round returns the result of rounding d to the nearest multiple of m,
+// as time.Duration.Round does
+func (d Duration) Round(m time.Duration) Duration {
+	return Duration(time.Duration(d).Round(m))
+}
+
+// Truncate returns the result of rounding d toward zero to a multiple of m,
+// as time.Duration.Truncate does
+func (d Duration) Truncate(m time.Duration) Duration {
+	return Duration(time.Duration(d).Truncate(m))
+}
+
+// Abs returns the absolute value of d, as time.Duration.Abs does,
+// the minimal negative duration is converted to the maximal positive one
+func (d Duration) Abs() Duration {
+	switch {
+	case d >= 0:
+		return d
+	case d == math.MinInt64:
+		return math.MaxInt64
+	}
+	return -d
+}
+
+// absNanos returns the absolute amount of nanoseconds in the duration,
+// unlike Abs, it keeps the magnitude of the minimal negative duration
+func (d Duration) absNanos() uint64 {
+	if d < 0 {
+		return uint64(-(d + 1)) + 1
+	}
+	return uint64(d)
+}
+
+// HMS returns the duration in the fixed "hh:mm:ss" form, like "01:05:03", fractions
+// of the second are dropped. Hours are not wrapped at 24, e.g. "100:00:00", and negative
+// durations of a second and longer are prefixed with "-".
+func (d Duration) HMS() string {
+	secs, sign := d.absNanos()/uint64(time.Second), ""
+	if d < 0 && secs > 0 {
+		sign = "-"
+	}
+	return fmt.Sprintf("%s%02d:%02d:%02d", sign, secs/3600, secs/60%60, secs%60)
+}
+
+// Split decomposes the absolute value of the duration into days, considering them to be
+// exactly 24 hours long, hours, minutes, seconds and nanoseconds, e.g. 26h5m3.5s is 1 day,
+// 2 hours, 5 minutes, 3 seconds and 500000000 nanoseconds. neg reports whether the
+// duration is negative.
+func (d Duration) Split() (days, hours, minutes, seconds, nanos int, neg bool) {
+	abs := d.absNanos()
+	secs := abs / uint64(time.Second)
+	return int(secs / (24 * 60 * 60)), int(secs / (60 * 60) % 24), int(secs / 60 % 60), int(secs % 60),
+		int(abs % uint64(time.Second)), d < 0
+}
+
+// ParseHMS parses the duration in the fixed form, as HMS returns it, either "hh:mm:ss",
+// like "01:05:03" or "100:00:00", or "mm:ss", like "05:03", with the optional leading "-"
+// and fractions of the second, like "00:00:03.5". Fields after the leading one must be
+// less than 60.
+func ParseHMS(s string) (Duration, error) {
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	fields := strings.Split(s, ":")
+	units := []uint64{uint64(time.Hour), uint64(time.Minute), uint64(time.Second)}
+	switch len(fields) {
+	case 2:
+		units = units[1:]
+	case 3:
+	default:
+		return 0, ErrInvalidDuration
+	}
+
+	var total uint64
+	for i, field := range fields {
+		intPart, fracPart, hasFrac := field, "", false
+		if i == len(fields)-1 {
+			intPart, fracPart, hasFrac = strings.Cut(field, ".")
+		}
+		if !isDigits(intPart) || (hasFrac && !isDigits(fracPart)) {
+			return 0, ErrInvalidDuration
+		}
+		v, err := iso8601Component(field, units[i])
+		if err != nil {
+			return 0, err
+		}
+		if (i > 0 && v >= 60*units[i]) || v > 1<<63-total {
+			return 0, ErrInvalidDuration
+		}
+		total += v
+	}
+	return signedDuration(neg, total)
+}
+
+// Clamp returns min if d is less than min, max if d is greater than max and d otherwise.
+// If min is greater than max, min is returned.
+func (d Duration) Clamp(min, max Duration) Duration {
+	switch {
+	case d < min, min > max:
+		return min
+	case d > max:
+		return max
+	}
+	return d
+}
+
+// HumanString returns the duration in a human-readable form, like "1 hour 5 minutes 3 seconds".
+// Zero components are omitted, fractions of the second are dropped and durations shorter
+// than a second are rendered as "0 seconds". Negative durations are prefixed with "negative ".
+func (d Duration) HumanString() string {
+	u := uint64(d)
+	b := make([]byte, 0, 64)
+	if d < 0 {
+		u = -u
+		b = append(b, "negative "...)
+	}
+	secs := u / 1e9
+	if secs == 0 {
+		return string(append(b, "0 seconds"...))
+	}
+
+	units := [...]struct {
+		name string
+		secs uint64
+	}{{"day", 24 * 60 * 60}, {"hour", 60 * 60}, {"minute", 60}, {"second", 1}}
+	first := true
+	for _, unit := range units {
+		n := secs / unit.secs
+		secs %= unit.secs
+		if n == 0 {
+			continue
+		}
+		if !first {
+			b = append(b, ' ')
+		}
+		first = false
+		b = append(strconv.AppendUint(b, n, 10), ' ')
+		b = append(b, unit.name...)
+		if n > 1 {
+			b = append(b, 's')
+		}
+	}
+	return string(b)
+}
+
+// StringDuration is a Duration, that is stored in SQL as a string, like "1h5m3s",
+// to keep it human-readable in text columns
+type StringDuration Duration
+
+// Scan the given SQL value as StringDuration
+func (d *StringDuration) Scan(src interface{}) error {
+	return (*Duration)(d).Scan(src)
+}
+
+// Value returns the SQL value of the given StringDuration
+func (d StringDuration) Value() (driver.Value, error) {
+	if rejectNegativeDuration && d < 0 {
+		return nil, ErrInvalidDuration
+	}
+	return Duration(d).String(), nil
+}
+
 // errExternal wraps an error come outside this package (e.g. from time.ParseDuration).
 // It allows to detect the external error inside tests by asserting the type of an error.
 type errExternal struct {
-	error // wrapped error
+	op    string // operation, that failed, like "scan" or "unmarshal"
+	typ   string // type, the operation was made with, like "duration"
+	error        // wrapped error
 }
 
-// Error returns the error string of the wrapped error
+// Error returns the error string of the wrapped error with the operation
+// and the type, like "timetype: scan duration: <wrapped error>"
 func (e *errExternal) Error() string {
-	return e.error.Error()
+	return "timetype: " + e.op + " " + e.typ + ": " + e.error.Error()
 }
 
-func wrapExternalErr(e error) error {
+// Unwrap returns the wrapped error
+func (e *errExternal) Unwrap() error {
+	return e.error
+}
+
+// wrapExternalErr wraps the error, that occurred in the operation op
+// with the type typ, or returns nil if the error is nil
+func wrapExternalErr(op, typ string, e error) error {
 	if e == nil {
 		return nil
 	}
-	return &errExternal{error: e}
+	return &errExternal{op: op, typ: typ, error: e}
+}
+
+// withErrOp replaces the operation of the external error, so the error of parsing,
+// that happened inside of the outer operation, e.g. Scan, mentions the outer one
+func withErrOp(op string, err error) error {
+	if ee, ok := err.(*errExternal); ok {
+		ee.op = op
+	}
+	return err
 }
@@ -1,8 +1,16 @@
 package timetype
 
 import (
+	"bytes"
+	"context"
+	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
 	"errors"
+	"fmt"
+	"math"
+	"strconv"
 	"testing"
 	"time"
 
@@ -20,6 +28,46 @@ func TestClock_String(t *testing.T) {
 	assert.Equal(t, "17:54:00 UTC", s)
 }
 
+func TestAppendClockMicro(t *testing.T) {
+	plus2 := time.FixedZone("", 2*60*60)
+	clocks := []Clock{
+		NewUTCClock(0, 0, 0, 0),
+		NewUTCClock(1, 2, 3, 4000),
+		NewUTCClock(9, 5, 7, 999),
+		NewUTCClock(19, 24, 5, 123456789),
+		NewUTCClock(23, 59, 59, 999999999),
+		NewUTCClock(10, 10, 10, 100000000),
+		NewClock(7, 0, 0, 500, plus2),
+	}
+	for off := time.Duration(0); off < day; off += 7*time.Minute + 13*time.Second + 1234567 {
+		clocks = append(clocks, ClockFromDuration(off, time.UTC))
+	}
+	for i, c := range clocks {
+		expected := time.Time(c).Format(ISO8601ClockMicro)
+		assert.Equal(t, expected, c.Format(ISO8601ClockMicro), "case #%d", i)
+		v, err := c.Value()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, expected, v, "case #%d", i)
+	}
+
+	assert.Equal(t, "24:00:00.000000", EndOfDay(plus2).Format(ISO8601ClockMicro))
+	assert.Equal(t, "prefix 07:00:00.000000", string(NewUTCClock(7, 0, 0, 0).AppendFormat([]byte("prefix "))))
+}
+
+func TestClock_ShortString(t *testing.T) {
+	tbl := []struct {
+		arg      Clock
+		expected string
+	}{
+		{arg: NewUTCClock(17, 54, 0, 0), expected: "17:54:00"},
+		{arg: NewClock(7, 5, 3, 123456789, time.FixedZone("UTC+3", 3*60*60)), expected: "07:05:03"},
+		{arg: EndOfDay(time.UTC), expected: "24:00:00"},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.ShortString(), "case #%d", i)
+	}
+}
+
 func TestClock_UnmarshalJSON(t *testing.T) {
 	var c Clock
 	err := c.UnmarshalJSON([]byte("\"19:24:00.000000\""))
@@ -40,193 +88,1756 @@ func TestClock_UnmarshalJSON(t *testing.T) {
 	assert.IsType(t, &UnknownFormatError{}, err, "invalid character \"c\" in seconds")
 }
 
+func TestClock_UnmarshalJSON_TwelveHour(t *testing.T) {
+	tbl := []struct {
+		arg      string
+		expected Clock
+	}{
+		{arg: `"7:24 PM"`, expected: NewUTCClock(19, 24, 0, 0)},
+		{arg: `"11:05 AM"`, expected: NewUTCClock(11, 5, 0, 0)},
+		{arg: `"12:00 AM"`, expected: NewUTCClock(0, 0, 0, 0)},
+		{arg: `"12:30 PM"`, expected: NewUTCClock(12, 30, 0, 0)},
+		{arg: `"7:24:15 PM"`, expected: NewUTCClock(19, 24, 15, 0)},
+	}
+	for i, tt := range tbl {
+		var c Clock
+		require.NoError(t, c.UnmarshalJSON([]byte(tt.arg)), "case #%d", i)
+		assert.Equal(t, tt.expected, c, "case #%d", i)
+	}
+
+	var c Clock
+	err := c.UnmarshalJSON([]byte(`"13:00 PM"`))
+	assert.EqualError(t, err, "timetype: failed to parse \"13:00 PM\" in layouts: "+
+		"[\"15:04:05\", \"15:04:05.000000\", \"15:04:05.000000000\", \"15:04:05Z07:00\", \"15:04:05.000000Z07:00\", \"3:04 PM\", \"3:04:05 PM\", \"15:04:05-07\", \"15:04:05.000000-07:00\", \"15:04\"]")
+}
+
+func TestParseClock(t *testing.T) {
+	tbl := []struct {
+		arg      string
+		expected Clock
+	}{
+		{arg: "19:24:00", expected: NewUTCClock(19, 24, 0, 0)},
+		{arg: "19:24:00.000000", expected: NewUTCClock(19, 24, 0, 0)},
+		{arg: "19:24:05.123456789", expected: NewUTCClock(19, 24, 5, 123456789)},
+		{arg: "7:24 PM", expected: NewUTCClock(19, 24, 0, 0)},
+		{arg: "7:24:15 PM", expected: NewUTCClock(19, 24, 15, 0)},
+		{arg: "19:24:00+02:00", expected: NewClock(19, 24, 0, 0, time.FixedZone("", 2*60*60))},
+		{arg: " 19:24:00", expected: NewUTCClock(19, 24, 0, 0)},
+		{arg: "19:24:00 ", expected: NewUTCClock(19, 24, 0, 0)},
+		{arg: "\t7:24 PM\n", expected: NewUTCClock(19, 24, 0, 0)},
+		{arg: "19:24", expected: NewUTCClock(19, 24, 0, 0)},
+		{arg: "9:05", expected: NewUTCClock(9, 5, 0, 0)},
+		{arg: "00:00", expected: NewUTCClock(0, 0, 0, 0)},
+	}
+	for i, tt := range tbl {
+		c, err := ParseClock(tt.arg)
+		require.NoError(t, err, "case #%d", i)
+		assert.True(t, tt.expected.Equal(c), "case #%d", i)
+		assert.Equal(t, tt.expected.String(), c.String(), "case #%d", i)
+	}
+
+	_, err := ParseClock("19:24:c00.000000")
+	require.Error(t, err)
+	assert.IsType(t, &UnknownFormatError{}, err)
+
+	_, err = ParseClock("19:24: 00")
+	require.Error(t, err)
+	assert.IsType(t, &UnknownFormatError{}, err)
+
+	_, err = ParseClock("19:60")
+	require.Error(t, err)
+	assert.IsType(t, &UnknownFormatError{}, err)
+
+	var c Clock
+	require.NoError(t, c.UnmarshalJSON([]byte(`"19:24"`)))
+	assert.Equal(t, NewUTCClock(19, 24, 0, 0), c)
+	require.NoError(t, c.Scan([]byte("9:05")))
+	assert.Equal(t, NewUTCClock(9, 5, 0, 0), c)
+	require.NoError(t, c.Scan("24:00"))
+	assert.Equal(t, EndOfDay(time.UTC), c)
+
+	require.NoError(t, c.UnmarshalJSON([]byte(`" 19:24:00 "`)))
+	assert.Equal(t, NewUTCClock(19, 24, 0, 0), c)
+	require.NoError(t, c.Scan(" 24:00:00"))
+	assert.Equal(t, EndOfDay(time.UTC), c)
+
+	_, err = ParseClock("13:00 PM")
+	assert.EqualError(t, err, "timetype: failed to parse \"13:00 PM\" in layouts: "+
+		"[\"15:04:05\", \"15:04:05.000000\", \"15:04:05.000000000\", \"15:04:05Z07:00\", \"15:04:05.000000Z07:00\", \"3:04 PM\", \"3:04:05 PM\", \"15:04:05-07\", \"15:04:05.000000-07:00\", \"15:04\"]")
+}
+
+func TestParseClocks(t *testing.T) {
+	res, err := ParseClocks("09:00:00, 12:30:00 ,7:45 PM", ",")
+	require.NoError(t, err)
+	assert.Equal(t, []Clock{NewUTCClock(9, 0, 0, 0), NewUTCClock(12, 30, 0, 0), NewUTCClock(19, 45, 0, 0)}, res)
+
+	res, err = ParseClocks("09:00:00;abacaba;12:30:00", ";")
+	require.Error(t, err)
+	assert.Nil(t, res)
+	assert.Contains(t, err.Error(), "clock #1: timetype: failed to parse \"abacaba\"")
+	var ue *UnknownFormatError
+	assert.True(t, errors.As(err, &ue))
+
+	for i, tt := range []string{"", "  "} {
+		res, err = ParseClocks(tt, ",")
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, []Clock{}, res, "case #%d", i)
+	}
+}
+
+func TestParseClockInLocation(t *testing.T) {
+	loc := time.FixedZone("", 3*60*60)
+
+	c, err := ParseClockInLocation("19:24:00", loc)
+	require.NoError(t, err)
+	assert.Equal(t, NewClock(19, 24, 0, 0, loc), c)
+
+	c, err = ParseClockInLocation("7:24 PM", loc)
+	require.NoError(t, err)
+	assert.Equal(t, NewClock(19, 24, 0, 0, loc), c)
+
+	c, err = ParseClockInLocation("19:24:00Z", loc)
+	require.NoError(t, err)
+	assert.Equal(t, NewUTCClock(19, 24, 0, 0), c)
+
+	_, err = ParseClockInLocation("abacaba", loc)
+	require.Error(t, err)
+	assert.IsType(t, &UnknownFormatError{}, err)
+}
+
 func TestNewClock(t *testing.T) {
 	assert.Equal(t, Clock(time.Date(0, time.January, 1, 13, 24, 32, 0, time.Local)),
 		NewClock(13, 24, 32, 0, time.Local))
 
 	assert.Equal(t, Clock(time.Date(0, time.January, 1, 23, 59, 59, 0, time.UTC)),
 		NewUTCClock(23, 59, 59, 0))
+
+	assert.Panics(t, func() { NewClock(25, 70, 99, 0, time.UTC) })
+	assert.Panics(t, func() { NewUTCClock(24, 0, 0, 0) })
 }
 
-func TestErrExternal_Error(t *testing.T) {
-	assert.EqualError(t, wrapExternalErr(errors.New("some test error")), "some test error")
+func TestNewClockChecked(t *testing.T) {
+	tbl := []struct {
+		h, m, s, ns int
+		err         error
+	}{
+		{h: 0, m: 0, s: 0, ns: 0},
+		{h: 23, m: 59, s: 59, ns: 999999999},
+		{h: -1, m: 0, s: 0, ns: 0, err: ErrInvalidClock},
+		{h: 24, m: 0, s: 0, ns: 0, err: ErrInvalidClock},
+		{h: 0, m: -1, s: 0, ns: 0, err: ErrInvalidClock},
+		{h: 0, m: 60, s: 0, ns: 0, err: ErrInvalidClock},
+		{h: 0, m: 0, s: -1, ns: 0, err: ErrInvalidClock},
+		{h: 0, m: 0, s: 60, ns: 0, err: ErrInvalidClock},
+		{h: 0, m: 0, s: 0, ns: -1, err: ErrInvalidClock},
+		{h: 0, m: 0, s: 0, ns: 1000000000, err: ErrInvalidClock},
+	}
+	for i, tt := range tbl {
+		c, err := NewClockChecked(tt.h, tt.m, tt.s, tt.ns, time.UTC)
+		if tt.err != nil {
+			assert.Equal(t, tt.err, err, "case #%d", i)
+			assert.Equal(t, Clock{}, c, "case #%d", i)
+			continue
+		}
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, Clock(time.Date(0, time.January, 1, tt.h, tt.m, tt.s, tt.ns, time.UTC)), c, "case #%d", i)
+	}
 }
 
-func TestDuration_UnmarshalJSON(t *testing.T) {
-	var d Duration
-	err := d.UnmarshalJSON([]byte("\"1h5m3s\""))
+func TestNow(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	now = func() time.Time { return time.Date(2020, time.October, 15, 19, 24, 32, 1000, time.UTC) }
+
+	c := NowUTC()
+	assert.Equal(t, NewUTCClock(19, 24, 32, 1000), c)
+	tm := time.Time(c)
+	assert.Equal(t, 0, tm.Year())
+	assert.Equal(t, time.January, tm.Month())
+	assert.Equal(t, 1, tm.Day())
+
+	plus3 := time.FixedZone("UTC+3", 3*60*60)
+	assert.Equal(t, NewClock(22, 24, 32, 1000, plus3), Now(plus3))
+
+	b, err := c.MarshalJSON()
 	require.NoError(t, err)
-	assert.Equal(t, Duration(time.Hour+5*time.Minute+3*time.Second), d)
+	assert.Equal(t, []byte(`"19:24:32.000001"`), b)
+}
 
-	err = d.UnmarshalJSON([]byte("3903000000000"))
+func TestClock_Add(t *testing.T) {
+	loc := time.FixedZone("Test", 3*60*60)
+	tbl := []struct {
+		clock    Clock
+		d        time.Duration
+		expected Clock
+	}{
+		{clock: NewUTCClock(13, 24, 0, 0), d: 30 * time.Minute, expected: NewUTCClock(13, 54, 0, 0)},
+		{clock: NewUTCClock(23, 30, 0, 0), d: time.Hour, expected: NewUTCClock(0, 30, 0, 0)},
+		{clock: NewUTCClock(0, 30, 0, 0), d: -time.Hour, expected: NewUTCClock(23, 30, 0, 0)},
+		{clock: NewUTCClock(23, 59, 59, 999999999), d: time.Nanosecond, expected: NewUTCClock(0, 0, 0, 0)},
+		{clock: NewUTCClock(0, 0, 0, 0), d: -time.Nanosecond, expected: NewUTCClock(23, 59, 59, 999999999)},
+		{clock: NewUTCClock(10, 0, 0, 0), d: 50 * time.Hour, expected: NewUTCClock(12, 0, 0, 0)},
+		{clock: NewUTCClock(10, 0, 0, 0), d: -50 * time.Hour, expected: NewUTCClock(8, 0, 0, 0)},
+		{clock: NewUTCClock(10, 0, 0, 0), d: 24 * time.Hour, expected: NewUTCClock(10, 0, 0, 0)},
+		{clock: NewClock(23, 0, 0, 0, loc), d: 2 * time.Hour, expected: NewClock(1, 0, 0, 0, loc)},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.clock.Add(tt.d), "case #%d", i)
+	}
+}
+
+func TestClock_AddIn(t *testing.T) {
+	plus3 := time.FixedZone("UTC+3", 3*60*60)
+	minus5 := time.FixedZone("UTC-5", -5*60*60)
+	tbl := []struct {
+		clock    Clock
+		d        time.Duration
+		loc      *time.Location
+		expected Clock
+	}{
+		{clock: NewUTCClock(23, 0, 0, 0), d: 2 * time.Hour, loc: plus3, expected: NewClock(4, 0, 0, 0, plus3)},
+		{clock: NewUTCClock(10, 0, 0, 0), d: 30 * time.Minute, loc: time.UTC, expected: NewUTCClock(10, 30, 0, 0)},
+		{clock: NewClock(1, 0, 0, 0, plus3), d: -time.Hour, loc: time.UTC, expected: NewUTCClock(21, 0, 0, 0)},
+		{clock: NewClock(20, 0, 0, 0, minus5), d: 3 * time.Hour, loc: plus3, expected: NewClock(7, 0, 0, 0, plus3)},
+		{clock: NewClock(12, 0, 0, 0, plus3), d: 48 * time.Hour, loc: minus5, expected: NewClock(4, 0, 0, 0, minus5)},
+	}
+	for i, tt := range tbl {
+		res := tt.clock.AddIn(tt.d, tt.loc)
+		assert.Equal(t, tt.expected, res, "case #%d", i)
+		assert.Equal(t, tt.loc, res.Location(), "case #%d", i)
+		assert.Equal(t, tt.clock.Add(tt.d).Key(), res.Key(), "case #%d", i)
+	}
+
+	scanned := Clock{}
+	require.NoError(t, scanned.Scan("23:00:00"))
+	assert.Equal(t, time.UTC, scanned.Add(time.Hour).Location(), "Add keeps the location")
+}
+
+func TestClock_AddWithDays(t *testing.T) {
+	loc := time.FixedZone("Test", 3*60*60)
+	tbl := []struct {
+		clock    Clock
+		d        time.Duration
+		expected Clock
+		days     int
+	}{
+		{clock: NewUTCClock(13, 24, 0, 0), d: 30 * time.Minute, expected: NewUTCClock(13, 54, 0, 0), days: 0},
+		{clock: NewUTCClock(23, 30, 0, 0), d: 3 * time.Hour, expected: NewUTCClock(2, 30, 0, 0), days: 1},
+		{clock: NewUTCClock(0, 30, 0, 0), d: -time.Hour, expected: NewUTCClock(23, 30, 0, 0), days: -1},
+		{clock: NewUTCClock(23, 59, 59, 999999999), d: time.Nanosecond, expected: NewUTCClock(0, 0, 0, 0), days: 1},
+		{clock: NewUTCClock(0, 0, 0, 0), d: -time.Nanosecond, expected: NewUTCClock(23, 59, 59, 999999999), days: -1},
+		{clock: NewUTCClock(0, 0, 0, 0), d: 0, expected: NewUTCClock(0, 0, 0, 0), days: 0},
+		{clock: NewUTCClock(10, 0, 0, 0), d: 50 * time.Hour, expected: NewUTCClock(12, 0, 0, 0), days: 2},
+		{clock: NewUTCClock(10, 0, 0, 0), d: -50 * time.Hour, expected: NewUTCClock(8, 0, 0, 0), days: -2},
+		{clock: NewUTCClock(10, 0, 0, 0), d: 24 * time.Hour, expected: NewUTCClock(10, 0, 0, 0), days: 1},
+		{clock: NewUTCClock(10, 0, 0, 0), d: -24 * time.Hour, expected: NewUTCClock(10, 0, 0, 0), days: -1},
+		{clock: NewUTCClock(10, 0, 0, 0), d: 14 * time.Hour, expected: NewUTCClock(0, 0, 0, 0), days: 1},
+		{clock: NewUTCClock(10, 0, 0, 0), d: -10 * time.Hour, expected: NewUTCClock(0, 0, 0, 0), days: 0},
+		{clock: NewClock(23, 0, 0, 0, loc), d: 2 * time.Hour, expected: NewClock(1, 0, 0, 0, loc), days: 1},
+	}
+	for i, tt := range tbl {
+		c, days := tt.clock.AddWithDays(tt.d)
+		assert.Equal(t, tt.expected, c, "case #%d", i)
+		assert.Equal(t, tt.days, days, "case #%d", i)
+		assert.Equal(t, tt.clock.Add(tt.d), c, "case #%d", i)
+	}
+
+	c, days := NewUTCClock(12, 0, 0, 0).AddWithDays(math.MaxInt64)
+	assert.Equal(t, NewUTCClock(12, 0, 0, 0).Add(math.MaxInt64), c)
+	assert.Equal(t, 106752, days)
+}
+
+func TestClock_Sub(t *testing.T) {
+	tbl := []struct {
+		a, b     Clock
+		expected time.Duration
+	}{
+		{a: NewUTCClock(17, 0, 0, 0), b: NewUTCClock(9, 0, 0, 0), expected: 8 * time.Hour},
+		{a: NewUTCClock(9, 0, 0, 0), b: NewUTCClock(17, 0, 0, 0), expected: -8 * time.Hour},
+		{a: NewUTCClock(0, 0, 0, 0), b: NewUTCClock(23, 59, 59, 999999999), expected: -(day - time.Nanosecond)},
+		{a: NewUTCClock(23, 59, 59, 999999999), b: NewUTCClock(0, 0, 0, 0), expected: day - time.Nanosecond},
+		{a: NewUTCClock(12, 0, 0, 0), b: NewUTCClock(12, 0, 0, 0), expected: 0},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.a.Sub(tt.b), "case #%d", i)
+		assert.Equal(t, tt.expected, tt.a.Minus(tt.b), "case #%d", i)
+	}
+
+	// unlike DiffAcross, Minus doesn't wrap past midnight
+	d, _ := NewUTCClock(17, 0, 0, 0).DiffAcross(NewUTCClock(9, 0, 0, 0))
+	assert.Equal(t, 16*time.Hour, d)
+	assert.Equal(t, -8*time.Hour, NewUTCClock(9, 0, 0, 0).Minus(NewUTCClock(17, 0, 0, 0)))
+}
+
+func TestClock_On(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
 	require.NoError(t, err)
-	assert.Equal(t, Duration(time.Hour+5*time.Minute+3*time.Second), d)
+	plus3 := time.FixedZone("UTC+3", 3*60*60)
 
-	// errors
-	err = d.UnmarshalJSON([]byte("true"))
-	require.EqualError(t, err, "timetype: invalid duration", "passed bool to type duration")
-	assert.Equal(t, ErrInvalidDuration, err)
+	c := NewUTCClock(9, 0, 0, 500)
+	assert.Equal(t, time.Date(2023, time.May, 1, 9, 0, 0, 500, time.UTC), c.On(2023, time.May, 1, time.UTC))
+	assert.Equal(t, time.Date(2023, time.May, 1, 9, 0, 0, 500, ny), c.On(2023, time.May, 1, ny))
+	assert.Equal(t, time.Date(2023, time.May, 1, 9, 0, 0, 0, plus3),
+		NewClock(9, 0, 0, 0, plus3).On(2023, time.May, 1, nil), "clock's location is used")
 
-	err = d.UnmarshalJSON([]byte("1h5m3s"))
-	require.Error(t, err)
-	assert.IsType(t, &errExternal{}, err, "duration should be escaped in quotes or passed as integer")
+	// spring forward, 02:30 doesn't exist, so it's 02:30 either in EST or in EDT
+	tm := NewUTCClock(2, 30, 0, 0).On(2021, time.March, 14, ny)
+	assert.Contains(t, []string{"2021-03-14T07:30:00Z", "2021-03-14T06:30:00Z"}, tm.UTC().Format(time.RFC3339))
 
-	err = d.UnmarshalJSON([]byte("\"123\""))
-	require.Error(t, err)
-	assert.IsType(t, &errExternal{}, err, "passed empty string to time parser")
+	// fall back, 01:30 happens twice
+	tm = NewUTCClock(1, 30, 0, 0).On(2021, time.November, 7, ny)
+	assert.Equal(t, 1, tm.Hour())
+	assert.Equal(t, 30, tm.Minute())
+
+	// regular offsets around the transitions
+	assert.Equal(t, "2021-03-13T09:00:00-05:00", NewUTCClock(9, 0, 0, 0).On(2021, time.March, 13, ny).Format(time.RFC3339))
+	assert.Equal(t, "2021-03-14T09:00:00-04:00", NewUTCClock(9, 0, 0, 0).On(2021, time.March, 14, ny).Format(time.RFC3339))
 }
 
-func TestUnknownFormatError_Error(t *testing.T) {
-	ue := UnknownFormatError{
-		Errors: []error{
-			errors.New("one"),
-			errors.New("two"),
-			errors.New("three"),
-		},
-		Layouts: []string{
-			ISO8601Clock,
-			ISO8601ClockMicro,
-			"some unknown layout",
-		},
-		Val: "123456",
+func TestClock_OnDate(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	date := time.Date(2021, time.March, 14, 23, 15, 0, 0, ny)
+	assert.Equal(t, time.Date(2021, time.March, 14, 9, 0, 0, 0, ny), NewUTCClock(9, 0, 0, 0).OnDate(date))
+
+	date = time.Date(2021, time.March, 15, 2, 0, 0, 0, time.UTC) // the 14th in New York
+	assert.Equal(t, time.Date(2021, time.March, 15, 9, 0, 0, 0, time.UTC), NewUTCClock(9, 0, 0, 0).OnDate(date))
+	assert.Equal(t, time.Date(2021, time.March, 14, 9, 0, 0, 0, ny), NewUTCClock(9, 0, 0, 0).OnDate(date.In(ny)))
+}
+
+func TestClockFromDuration(t *testing.T) {
+	plus3 := time.FixedZone("", 3*60*60)
+	tbl := []struct {
+		arg      time.Duration
+		loc      *time.Location
+		expected Clock
+	}{
+		{arg: 90 * time.Minute, loc: time.UTC, expected: NewUTCClock(1, 30, 0, 0)},
+		{arg: 19*time.Hour + 24*time.Minute + 5*time.Second + 1, loc: plus3, expected: NewClock(19, 24, 5, 1, plus3)},
+		{arg: 0, loc: time.UTC, expected: NewUTCClock(0, 0, 0, 0)},
+		{arg: 24 * time.Hour, loc: time.UTC, expected: NewUTCClock(0, 0, 0, 0)},
+		{arg: 25 * time.Hour, loc: time.UTC, expected: NewUTCClock(1, 0, 0, 0)},
+		{arg: 50*time.Hour + time.Minute, loc: time.UTC, expected: NewUTCClock(2, 1, 0, 0)},
+		{arg: -30 * time.Minute, loc: time.UTC, expected: NewUTCClock(23, 30, 0, 0)},
+		{arg: -25 * time.Hour, loc: plus3, expected: NewClock(23, 0, 0, 0, plus3)},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, ClockFromDuration(tt.arg, tt.loc), "case #%d", i)
 	}
-	assert.EqualError(t, &ue,
-		"timetype: failed to parse \"123456\" in layouts: [\"15:04:05\", \"15:04:05.000000\", \"some unknown layout\"]")
 }
 
-func TestDuration_Scan(t *testing.T) {
+func TestClock_Key(t *testing.T) {
+	defer func(loc *time.Location) { time.Local = loc }(time.Local)
+	time.Local = time.FixedZone("UTC+3", 3*60*60)
+
+	local := NewClock(15, 0, 0, 0, time.Local)
+	utc := NewUTCClock(12, 0, 0, 0)
+	assert.NotEqual(t, local, utc)
+	assert.Equal(t, local.Key(), utc.Key())
+	assert.Equal(t, int64(12*time.Hour), utc.Key())
+
 	tbl := []struct {
-		arg      interface{}
-		expected Duration
-		err      string
+		arg      Clock
+		expected int64
+	}{
+		{arg: NewUTCClock(0, 0, 0, 0), expected: 0},
+		{arg: NewUTCClock(23, 59, 59, 999999999), expected: int64(day - 1)},
+		{arg: NewClock(1, 0, 0, 0, time.FixedZone("", 3*60*60)), expected: int64(22 * time.Hour)},
+		{arg: NewClock(19, 24, 5, 1, time.FixedZone("", -5*60*60)), expected: int64(24*time.Minute + 5*time.Second + 1)},
+		{arg: EndOfDay(time.UTC), expected: 0},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.Key(), "case #%d", i)
+	}
+
+	set := map[int64]bool{}
+	for _, c := range []Clock{utc, local, utc.In(time.FixedZone("", -7*60*60)), NewUTCClock(13, 0, 0, 0)} {
+		set[c.Key()] = true
+	}
+	assert.Len(t, set, 2)
+}
+
+func TestClock_Between(t *testing.T) {
+	c := NewUTCClock
+	tbl := []struct {
+		start, end       Clock
+		incStart, incEnd bool
+		inside, outside  []Clock
 	}{
 		{
-			arg:      nil,
-			expected: Duration(0),
+			start: c(9, 0, 0, 0), end: c(17, 0, 0, 0), incStart: true, incEnd: true,
+			inside:  []Clock{c(9, 0, 0, 0), c(12, 0, 0, 0), c(17, 0, 0, 0)},
+			outside: []Clock{c(8, 59, 59, 999999999), c(17, 0, 0, 1)},
 		},
 		{
-			arg:      5 * time.Minute,
-			expected: Duration(5 * time.Minute),
+			start: c(9, 0, 0, 0), end: c(17, 0, 0, 0), incStart: true, incEnd: false,
+			inside:  []Clock{c(9, 0, 0, 0), c(16, 59, 59, 0)},
+			outside: []Clock{c(17, 0, 0, 0), c(8, 0, 0, 0)},
 		},
 		{
-			arg:      float64(10*time.Second + 1*time.Microsecond),
-			expected: Duration(10*time.Second + 1*time.Microsecond),
+			start: c(9, 0, 0, 0), end: c(17, 0, 0, 0), incStart: false, incEnd: true,
+			inside:  []Clock{c(9, 0, 0, 1), c(17, 0, 0, 0)},
+			outside: []Clock{c(9, 0, 0, 0), c(18, 0, 0, 0)},
 		},
 		{
-			arg:      int64(32 * time.Hour),
-			expected: Duration(32 * time.Hour),
+			start: c(9, 0, 0, 0), end: c(17, 0, 0, 0), incStart: false, incEnd: false,
+			inside:  []Clock{c(12, 0, 0, 0)},
+			outside: []Clock{c(9, 0, 0, 0), c(17, 0, 0, 0)},
 		},
 		{
-			arg:      time.Duration(32 * time.Hour),
-			expected: Duration(32 * time.Hour),
+			start: c(22, 0, 0, 0), end: c(6, 0, 0, 0), incStart: true, incEnd: true,
+			inside:  []Clock{c(22, 0, 0, 0), c(23, 0, 0, 0), c(0, 0, 0, 0), c(6, 0, 0, 0)},
+			outside: []Clock{c(7, 0, 0, 0), c(21, 59, 0, 0), c(12, 0, 0, 0)},
 		},
 		{
-			arg:      `"5h3m2s"`,
-			expected: Duration(5*time.Hour + 3*time.Minute + 2*time.Second),
+			start: c(22, 0, 0, 0), end: c(6, 0, 0, 0), incStart: true, incEnd: false,
+			inside:  []Clock{c(22, 0, 0, 0), c(5, 59, 59, 0)},
+			outside: []Clock{c(6, 0, 0, 0), c(7, 0, 0, 0)},
 		},
 		{
-			arg:      []byte(`"2h3m"`),
-			expected: Duration(2*time.Hour + 3*time.Minute),
+			start: c(22, 0, 0, 0), end: c(6, 0, 0, 0), incStart: false, incEnd: true,
+			inside:  []Clock{c(22, 0, 0, 1), c(6, 0, 0, 0)},
+			outside: []Clock{c(22, 0, 0, 0), c(7, 0, 0, 0)},
 		},
 		{
-			arg: 'c',
-			err: "timetype: invalid duration",
+			start: c(22, 0, 0, 0), end: c(6, 0, 0, 0), incStart: false, incEnd: false,
+			inside:  []Clock{c(23, 0, 0, 0), c(1, 0, 0, 0)},
+			outside: []Clock{c(22, 0, 0, 0), c(6, 0, 0, 0), c(12, 0, 0, 0)},
+		},
+		{
+			start: c(12, 0, 0, 0), end: c(12, 0, 0, 0), incStart: true, incEnd: true,
+			inside:  []Clock{c(12, 0, 0, 0)},
+			outside: []Clock{c(11, 0, 0, 0), c(13, 0, 0, 0)},
+		},
+		{
+			start: c(12, 0, 0, 0), end: c(12, 0, 0, 0), incStart: true, incEnd: false,
+			outside: []Clock{c(12, 0, 0, 0), c(13, 0, 0, 0)},
 		},
 	}
 	for i, tt := range tbl {
-		var d Duration
-		err := d.Scan(tt.arg)
-		if tt.err != "" {
-			assert.EqualError(t, err, tt.err, "case #%d", i)
-		} else {
-			assert.NoError(t, err, "case #%d", i)
+		for _, in := range tt.inside {
+			assert.True(t, in.Between(tt.start, tt.end, tt.incStart, tt.incEnd), "case #%d, %s", i, in)
+		}
+		for _, out := range tt.outside {
+			assert.False(t, out.Between(tt.start, tt.end, tt.incStart, tt.incEnd), "case #%d, %s", i, out)
 		}
-		assert.Equal(t, tt.expected, d, "case #%d", i)
 	}
 }
 
-func TestDuration_Value(t *testing.T) {
+func TestClock_NextOccurrence(t *testing.T) {
+	plus3 := time.FixedZone("", 3*60*60)
 	tbl := []struct {
-		arg      Duration
-		expected driver.Value
+		clock    Clock
+		after    time.Time
+		expected time.Time
 	}{
 		{
-			arg:      Duration(2*time.Hour + 3*time.Minute),
-			expected: int64(2*time.Hour + 3*time.Minute),
+			clock:    NewUTCClock(17, 0, 0, 0),
+			after:    time.Date(2023, time.May, 1, 9, 30, 0, 0, time.UTC),
+			expected: time.Date(2023, time.May, 1, 17, 0, 0, 0, time.UTC),
 		},
 		{
-			arg:      Duration(5*time.Hour + 3*time.Minute + 2*time.Second),
-			expected: int64(5*time.Hour + 3*time.Minute + 2*time.Second),
+			clock:    NewUTCClock(17, 0, 0, 0),
+			after:    time.Date(2023, time.May, 1, 18, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, time.May, 2, 17, 0, 0, 0, time.UTC),
 		},
 		{
-			arg:      Duration(1 * time.Second),
-			expected: int64(1 * time.Second),
+			clock:    NewUTCClock(17, 0, 0, 0),
+			after:    time.Date(2023, time.May, 1, 17, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, time.May, 2, 17, 0, 0, 0, time.UTC),
 		},
 		{
-			arg:      Duration(1 * time.Millisecond),
-			expected: int64(1 * time.Millisecond),
+			clock:    NewUTCClock(9, 0, 0, 0),
+			after:    time.Date(2023, time.December, 31, 23, 0, 0, 0, plus3),
+			expected: time.Date(2024, time.January, 1, 9, 0, 0, 0, plus3),
 		},
 		{
-			arg:      Duration(1 * time.Nanosecond),
-			expected: int64(1 * time.Nanosecond),
+			clock:    NewUTCClock(1, 0, 0, 0),
+			after:    time.Date(2023, time.May, 1, 23, 0, 0, 0, time.UTC).In(plus3), // 2nd of May in UTC+3
+			expected: time.Date(2023, time.May, 3, 1, 0, 0, 0, plus3),
 		},
 	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.clock.NextOccurrence(tt.after), "case #%d", i)
+	}
+}
 
+func TestClock_DiffAcross(t *testing.T) {
+	tbl := []struct {
+		from, to Clock
+		d        time.Duration
+	}{
+		{from: NewUTCClock(23, 0, 0, 0), to: NewUTCClock(1, 0, 0, 0), d: 2 * time.Hour},
+		{from: NewUTCClock(1, 0, 0, 0), to: NewUTCClock(23, 0, 0, 0), d: 22 * time.Hour},
+		{from: NewUTCClock(12, 0, 0, 0), to: NewUTCClock(12, 0, 0, 0), d: 0},
+		{from: NewUTCClock(0, 0, 0, 1), to: NewUTCClock(0, 0, 0, 0), d: day - time.Nanosecond},
+		{from: NewUTCClock(0, 0, 0, 0), to: NewUTCClock(23, 59, 59, 999999999), d: day - time.Nanosecond},
+	}
 	for i, tt := range tbl {
-		actual, err := tt.arg.Value()
-		assert.NoError(t, err)
-		assert.Equal(t, tt.expected, actual, "case #%d", i)
+		d, _ := tt.from.DiffAcross(tt.to)
+		assert.Equal(t, tt.d, d, "case #%d", i)
 	}
 }
 
-func TestDuration_MarshalJSON(t *testing.T) {
-	bytes, err := Duration(time.Hour + 5*time.Minute + 3*time.Second).MarshalJSON()
-	require.NoError(t, err)
-	assert.Equal(t, []byte(`"1h5m3s"`), bytes)
+func TestClock_Truncate(t *testing.T) {
+	loc := time.FixedZone("Test", 3*60*60)
+	tbl := []struct {
+		clock    Clock
+		d        time.Duration
+		expected Clock
+	}{
+		{clock: NewUTCClock(13, 24, 32, 100), d: time.Minute, expected: NewUTCClock(13, 24, 0, 0)},
+		{clock: NewUTCClock(13, 24, 32, 0), d: 15 * time.Minute, expected: NewUTCClock(13, 15, 0, 0)},
+		{clock: NewUTCClock(13, 15, 0, 0), d: 15 * time.Minute, expected: NewUTCClock(13, 15, 0, 0)},
+		{clock: NewUTCClock(13, 24, 32, 0), d: time.Hour, expected: NewUTCClock(13, 0, 0, 0)},
+		{clock: NewUTCClock(23, 59, 0, 0), d: 7 * time.Hour, expected: NewUTCClock(21, 0, 0, 0)},
+		{clock: NewUTCClock(23, 59, 0, 0), d: 48 * time.Hour, expected: NewUTCClock(0, 0, 0, 0)},
+		{clock: NewUTCClock(13, 24, 32, 0), d: 0, expected: NewUTCClock(13, 24, 32, 0)},
+		{clock: NewUTCClock(13, 24, 32, 0), d: -time.Hour, expected: NewUTCClock(13, 24, 32, 0)},
+		{clock: NewClock(13, 24, 32, 0, loc), d: time.Hour, expected: NewClock(13, 0, 0, 0, loc)},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.clock.Truncate(tt.d), "case #%d", i)
+	}
 }
 
-func TestClock_MarshalJSON(t *testing.T) {
-	bytes, err := Clock(time.Date(0, time.January, 1, 19, 24, 0, 0, time.UTC)).MarshalJSON()
-	require.NoError(t, err)
-	assert.Equal(t, []byte(`"19:24:00.000000"`), bytes)
+func TestClock_Clamp(t *testing.T) {
+	plus3 := time.FixedZone("", 3*60*60)
+	open, closed := NewUTCClock(9, 0, 0, 0), NewUTCClock(17, 0, 0, 0)
+	tbl := []struct {
+		arg, min, max Clock
+		expected      Clock
+	}{
+		{arg: NewUTCClock(8, 0, 0, 0), min: open, max: closed, expected: open},
+		{arg: NewUTCClock(0, 0, 0, 0), min: open, max: closed, expected: open},
+		{arg: NewUTCClock(12, 30, 0, 0), min: open, max: closed, expected: NewUTCClock(12, 30, 0, 0)},
+		{arg: NewUTCClock(9, 0, 0, 0), min: open, max: closed, expected: NewUTCClock(9, 0, 0, 0)},
+		{arg: NewUTCClock(17, 0, 0, 0), min: open, max: closed, expected: NewUTCClock(17, 0, 0, 0)},
+		{arg: NewUTCClock(20, 0, 0, 0), min: open, max: closed, expected: closed},
+		{arg: NewUTCClock(23, 59, 59, 0), min: open, max: closed, expected: closed},
+		{arg: NewClock(8, 0, 0, 0, plus3), min: open, max: closed, expected: open},
+		{arg: NewUTCClock(10, 0, 0, 0), min: open, max: open, expected: open},
+		// wrapping window
+		{arg: NewUTCClock(23, 0, 0, 0), min: NewUTCClock(22, 0, 0, 0), max: NewUTCClock(2, 0, 0, 0), expected: NewUTCClock(23, 0, 0, 0)},
+		{arg: NewUTCClock(1, 0, 0, 0), min: NewUTCClock(22, 0, 0, 0), max: NewUTCClock(2, 0, 0, 0), expected: NewUTCClock(1, 0, 0, 0)},
+		{arg: NewUTCClock(3, 0, 0, 0), min: NewUTCClock(22, 0, 0, 0), max: NewUTCClock(2, 0, 0, 0), expected: NewUTCClock(2, 0, 0, 0)},
+		{arg: NewUTCClock(21, 0, 0, 0), min: NewUTCClock(22, 0, 0, 0), max: NewUTCClock(2, 0, 0, 0), expected: NewUTCClock(22, 0, 0, 0)},
+		{arg: NewUTCClock(12, 0, 0, 0), min: NewUTCClock(22, 0, 0, 0), max: NewUTCClock(2, 0, 0, 0), expected: NewUTCClock(2, 0, 0, 0)},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.Clamp(tt.min, tt.max), "case #%d", i)
+	}
 }
 
-func TestClock_Scan(t *testing.T) {
+func TestClock_Compare(t *testing.T) {
+	plus3 := time.FixedZone("UTC+3", 3*60*60)
 	tbl := []struct {
-		arg      interface{}
-		expected Clock
-		err      string
+		a, b                 Clock
+		before, after, equal bool
+		cmp                  int
 	}{
-		{
-			arg:      nil,
-			expected: Clock(time.Time{}),
-		},
-		{
-			arg:      time.Date(0, time.January, 1, 2, 19, 30, 0, time.UTC),
-			expected: Clock(time.Date(0, time.January, 1, 2, 19, 30, 0, time.UTC)),
-		},
-		{
-			arg:      `19:24:00.000000`,
-			expected: Clock(time.Date(0, time.January, 1, 19, 24, 0, 0, time.UTC)),
-		},
-		{
-			arg:      []byte(`2:21:55.000000`),
-			expected: Clock(time.Date(0, time.January, 1, 2, 21, 55, 0, time.UTC)),
-		},
-		{
-			arg:      2567,
+		{a: NewUTCClock(9, 0, 0, 0), b: NewUTCClock(17, 0, 0, 0), before: true, cmp: -1},
+		{a: NewUTCClock(17, 0, 0, 0), b: NewUTCClock(9, 0, 0, 0), after: true, cmp: 1},
+		{a: NewUTCClock(12, 0, 0, 0), b: NewUTCClock(12, 0, 0, 0), equal: true},
+		{a: NewUTCClock(12, 0, 0, 0), b: NewUTCClock(12, 0, 0, 1), before: true, cmp: -1},
+		{a: NewUTCClock(12, 0, 0, 0), b: NewClock(15, 0, 0, 0, plus3), before: true, equal: true, cmp: -1},
+		{a: NewClock(10, 0, 0, 0, plus3), b: NewUTCClock(11, 0, 0, 0), before: true, cmp: -1},
+		{a: NewClock(12, 0, 0, 0, plus3), b: NewUTCClock(12, 0, 0, 0)},
+		{a: NewUTCClock(10, 0, 0, 0), b: NewClock(12, 0, 0, 0, plus3), before: true, cmp: -1},
+	}
+	// the example of Before: 12:00 in UTC+3 is the earlier instant than 10:00 in UTC
+	assert.True(t, time.Time(NewClock(12, 0, 0, 0, plus3)).Before(time.Time(NewUTCClock(10, 0, 0, 0))))
+	for i, tt := range tbl {
+		assert.Equal(t, tt.before, tt.a.Before(tt.b), "case #%d", i)
+		assert.Equal(t, tt.after, tt.a.After(tt.b), "case #%d", i)
+		assert.Equal(t, tt.equal, tt.a.Equal(tt.b), "case #%d", i)
+		assert.Equal(t, tt.cmp, tt.a.Compare(tt.b), "case #%d", i)
+		assert.Equal(t, -tt.cmp, tt.b.Compare(tt.a), "case #%d", i)
+	}
+}
+
+func TestClock_Accessors(t *testing.T) {
+	loc := time.FixedZone("", 2*60*60)
+	c := NewClock(19, 24, 5, 123456789, loc)
+	assert.Equal(t, 19, c.Hour())
+	assert.Equal(t, 24, c.Minute())
+	assert.Equal(t, 5, c.Second())
+	assert.Equal(t, 123456789, c.Nanosecond())
+	assert.Equal(t, loc, c.Location())
+	assert.Equal(t, time.UTC, NewUTCClock(0, 0, 0, 0).Location())
+}
+
+func TestClock_IsZero(t *testing.T) {
+	tbl := []struct {
+		arg      Clock
+		expected bool
+	}{
+		{arg: Clock{}, expected: true},
+		{arg: Clock(time.Time{}), expected: true},
+		{arg: NewUTCClock(0, 0, 0, 0), expected: false},
+		{arg: NewUTCClock(19, 24, 0, 0), expected: false},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.IsZero(), "case #%d", i)
+	}
+
+	var c Clock
+	require.NoError(t, c.Scan(nil))
+	assert.True(t, c.IsZero())
+}
+
+func TestClock_Normalize(t *testing.T) {
+	tbl := []struct {
+		arg      Clock
+		expected Clock
+	}{
+		{arg: NewUTCClock(19, 24, 5, 1), expected: NewUTCClock(19, 24, 5, 1)},
+		{arg: NewClock(19, 24, 0, 0, time.FixedZone("", 3*60*60)), expected: NewUTCClock(19, 24, 0, 0)},
+		{arg: NewClock(19, 24, 0, 0, time.Local), expected: NewUTCClock(19, 24, 0, 0)},
+		{arg: Clock(time.Date(2023, time.May, 1, 7, 30, 0, 0, time.FixedZone("", -5*60*60))), expected: NewUTCClock(7, 30, 0, 0)},
+		{arg: Clock{}, expected: Clock{}},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.Normalize(), "case #%d", i)
+	}
+
+	local := NewClock(19, 24, 0, 0, time.Local)
+	var scanned Clock
+	require.NoError(t, scanned.Scan("19:24:00"))
+	assert.Equal(t, local.Normalize(), scanned.Normalize())
+	require.NoError(t, scanned.Scan(time.Date(2023, time.May, 1, 19, 24, 0, 0, time.Local)))
+	assert.Equal(t, local.Normalize(), scanned.Normalize())
+}
+
+func TestClock_In(t *testing.T) {
+	plus2 := time.FixedZone("UTC+2", 2*60*60)
+	minus5 := time.FixedZone("UTC-5", -5*60*60)
+
+	assert.Equal(t, NewUTCClock(17, 24, 0, 0), NewClock(19, 24, 0, 0, plus2).UTC())
+	assert.Equal(t, NewUTCClock(23, 30, 0, 0), NewClock(1, 30, 0, 0, plus2).UTC(), "previous day")
+	assert.Equal(t, NewClock(1, 30, 0, 0, plus2), NewUTCClock(23, 30, 0, 0).In(plus2), "next day")
+	assert.Equal(t, NewClock(12, 24, 0, 0, minus5), NewClock(19, 24, 0, 0, plus2).In(minus5))
+	assert.True(t, NewClock(19, 24, 0, 0, plus2).Equal(NewClock(19, 24, 0, 0, plus2).In(minus5)))
+}
+
+func TestErrExternal_Error(t *testing.T) {
+	assert.EqualError(t, wrapExternalErr("scan", "duration", errors.New("some test error")),
+		"timetype: scan duration: some test error")
+	assert.NoError(t, wrapExternalErr("scan", "duration", nil))
+
+	unknownUnit := `time: unknown unit "hour" in duration "1hour"`
+	var d Duration
+	tbl := []struct {
+		err      error
+		expected string
+	}{
+		{err: d.Scan("1hour"), expected: "timetype: scan duration: " + unknownUnit},
+		{err: d.Scan([]byte("1hour")), expected: "timetype: scan duration: " + unknownUnit},
+		{err: d.UnmarshalJSON([]byte(`"1hour"`)), expected: "timetype: unmarshal duration: " + unknownUnit},
+		{err: d.UnmarshalText([]byte("1hour")), expected: "timetype: unmarshal duration: " + unknownUnit},
+		{err: func() error { _, err := ParseDuration("1hour"); return err }(), expected: "timetype: parse duration: " + unknownUnit},
+		{
+			err:      d.UnmarshalJSON([]byte(`1hour`)),
+			expected: "timetype: unmarshal duration: invalid character 'h' after top-level value",
+		},
+		{
+			err:      (&Clock{}).UnmarshalJSON([]byte(`19:24:00`)),
+			expected: "timetype: unmarshal clock: invalid character ':' after top-level value",
+		},
+		{
+			err:      (&Date{}).UnmarshalJSON([]byte(`2006-01-02`)),
+			expected: "timetype: unmarshal date: invalid character '-' after top-level value",
+		},
+	}
+	for i, tt := range tbl {
+		require.Error(t, tt.err, "case #%d", i)
+		assert.IsType(t, &errExternal{}, tt.err, "case #%d", i)
+		assert.EqualError(t, tt.err, tt.expected, "case #%d", i)
+	}
+}
+
+func TestErrExternal_Unwrap(t *testing.T) {
+	var d Duration
+	err := d.UnmarshalJSON([]byte(`"1hour"`))
+	require.Error(t, err)
+	assert.EqualError(t, errors.Unwrap(err), `time: unknown unit "hour" in duration "1hour"`)
+
+	var c Clock
+	err = c.UnmarshalJSON([]byte(`19:24:00`))
+	require.Error(t, err)
+	var se *json.SyntaxError
+	require.True(t, errors.As(err, &se))
+	assert.Equal(t, int64(3), se.Offset)
+
+	sentinel := errors.New("sentinel")
+	assert.True(t, errors.Is(wrapExternalErr("unmarshal", "clock", sentinel), sentinel))
+
+	assert.True(t, errors.Is(c.UnmarshalJSON([]byte(`1`)), ErrInvalidClock))
+	assert.True(t, errors.Is(d.UnmarshalJSON([]byte(`true`)), ErrInvalidDuration))
+}
+
+func TestUnknownFormatError_Unwrap(t *testing.T) {
+	var c Clock
+	err := c.UnmarshalJSON([]byte(`"19:24:c00"`))
+	require.Error(t, err)
+
+	var ue *UnknownFormatError
+	require.True(t, errors.As(err, &ue))
+	assert.Equal(t, "19:24:c00", ue.Val)
+	assert.Equal(t, clockLayouts, ue.Layouts)
+
+	var pe *time.ParseError
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, ISO8601Clock, pe.Layout)
+	assert.Equal(t, "19:24:c00", pe.Value)
+
+	sentinel := errors.New("sentinel")
+	assert.True(t, errors.Is(&UnknownFormatError{Errors: []error{errors.New("one"), sentinel}}, sentinel))
+}
+
+func TestDuration_UnmarshalJSON(t *testing.T) {
+	var d Duration
+	err := d.UnmarshalJSON([]byte("\"1h5m3s\""))
+	require.NoError(t, err)
+	assert.Equal(t, Duration(time.Hour+5*time.Minute+3*time.Second), d)
+
+	err = d.UnmarshalJSON([]byte("3903000000000"))
+	require.NoError(t, err)
+	assert.Equal(t, Duration(time.Hour+5*time.Minute+3*time.Second), d)
+
+	// errors
+	err = d.UnmarshalJSON([]byte("true"))
+	require.EqualError(t, err, "timetype: invalid duration", "passed bool to type duration")
+	assert.Equal(t, ErrInvalidDuration, err)
+
+	err = d.UnmarshalJSON([]byte("1h5m3s"))
+	require.Error(t, err)
+	assert.IsType(t, &errExternal{}, err, "duration should be escaped in quotes or passed as integer")
+
+	err = d.UnmarshalJSON([]byte("\"123\""))
+	require.Error(t, err)
+	assert.IsType(t, &errExternal{}, err, "passed empty string to time parser")
+
+	err = d.UnmarshalJSON([]byte("\"2x\""))
+	require.Error(t, err)
+	assert.IsType(t, &errExternal{}, err, "passed unknown unit to time parser")
+
+	err = d.UnmarshalJSON([]byte("\"7x2d\""))
+	require.Error(t, err)
+	assert.IsType(t, &errExternal{}, err, "passed unknown unit along with days to time parser")
+}
+
+func TestDuration_UnmarshalJSON_Days(t *testing.T) {
+	tbl := []struct {
+		arg      string
+		expected Duration
+	}{
+		{arg: `"7d"`, expected: Duration(168 * time.Hour)},
+		{arg: `"2w"`, expected: Duration(336 * time.Hour)},
+		{arg: `"1w2d12h"`, expected: Duration(228 * time.Hour)},
+		{arg: `"1h5m3s"`, expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+	}
+	for i, tt := range tbl {
+		var d Duration
+		require.NoError(t, d.UnmarshalJSON([]byte(tt.arg)), "case #%d", i)
+		assert.Equal(t, tt.expected, d, "case #%d", i)
+	}
+
+	var d Duration
+	assert.Equal(t, ErrAmbiguousUnit, d.UnmarshalJSON([]byte(`"1y"`)))
+}
+
+func TestDuration_UnmarshalJSON_Object(t *testing.T) {
+	var expected Duration
+	require.NoError(t, expected.UnmarshalJSON([]byte(`"1h5m3s"`)))
+
+	tbl := []struct {
+		arg      string
+		expected Duration
+	}{
+		{arg: `{"seconds": 3903, "nanos": 0}`, expected: expected},
+		{arg: `{"seconds": 3903}`, expected: expected},
+		{arg: `{"seconds": 1, "nanos": 500000000}`, expected: Duration(1500 * time.Millisecond)},
+		{arg: `{"seconds": -1, "nanos": -500000000}`, expected: Duration(-1500 * time.Millisecond)},
+		{arg: `{"nanos": -5}`, expected: Duration(-5)},
+		{arg: `{"seconds": 0}`, expected: 0},
+	}
+	for i, tt := range tbl {
+		var d Duration
+		require.NoError(t, d.UnmarshalJSON([]byte(tt.arg)), "case #%d", i)
+		assert.Equal(t, tt.expected, d, "case #%d", i)
+	}
+
+	for i, tt := range []string{
+		`{"seconds": 1, "nanos": 1000000000}`,
+		`{"seconds": 1, "nanos": -1}`,
+		`{"seconds": -1, "nanos": 1}`,
+		`{"seconds": 9223372037}`,
+		`{"seconds": 9223372036, "nanos": 854775808}`,
+	} {
+		d := Duration(time.Second)
+		assert.Equal(t, ErrInvalidDuration, d.UnmarshalJSON([]byte(tt)), "case #%d", i)
+		assert.Equal(t, Duration(time.Second), d, "case #%d", i)
+	}
+
+	d := Duration(time.Second)
+	assert.Equal(t, ErrInvalidDuration, d.UnmarshalJSON([]byte(`{}`)))
+	for i, tt := range []string{`{"seconds": "3903"}`, `{"foo": 1}`, `{"seconds": 1, "foo": 1}`} {
+		err := d.UnmarshalJSON([]byte(tt))
+		require.Error(t, err, "case #%d", i)
+		assert.IsType(t, &errExternal{}, err, "case #%d", i)
+	}
+	assert.Equal(t, Duration(time.Second), d)
+}
+
+func TestDuration_UnmarshalJSON_Pair(t *testing.T) {
+	tbl := []struct {
+		arg      string
+		expected Duration
+	}{
+		{arg: `[5, "minutes"]`, expected: Duration(5 * time.Minute)},
+		{arg: `[2, "hours"]`, expected: Duration(2 * time.Hour)},
+		{arg: `[1.5, "seconds"]`, expected: Duration(1500 * time.Millisecond)},
+		{arg: `[-3, "days"]`, expected: Duration(-72 * time.Hour)},
+		{arg: `[1, "weeks"]`, expected: Duration(168 * time.Hour)},
+		{arg: `[250, "microseconds"]`, expected: Duration(250 * time.Microsecond)},
+		{arg: `[7, "nanoseconds"]`, expected: Duration(7)},
+		{arg: `[0, "milliseconds"]`, expected: 0},
+	}
+	for i, tt := range tbl {
+		var d Duration
+		require.NoError(t, d.UnmarshalJSON([]byte(tt.arg)), "case #%d", i)
+		assert.Equal(t, tt.expected, d, "case #%d", i)
+	}
+
+	for i, tt := range []string{
+		`[5, "fortnights"]`, `[5, "Minutes"]`, `[5]`, `[]`, `[5, "minutes", 1]`,
+		`["5", "minutes"]`, `[5, 60]`, `[1000000, "weeks"]`,
+	} {
+		d := Duration(time.Second)
+		assert.Equal(t, ErrInvalidDuration, d.UnmarshalJSON([]byte(tt)), "case #%d", i)
+		assert.Equal(t, Duration(time.Second), d, "case #%d", i)
+	}
+}
+
+func TestSetDurationJSONFormat(t *testing.T) {
+	defer SetDurationJSONFormat(durationJSONFormat)
+
+	SetDurationJSONFormat(DurationJSONObject)
+	tbl := []struct {
+		arg      Duration
+		expected string
+	}{
+		{arg: Duration(time.Hour + 5*time.Minute + 3*time.Second), expected: `{"seconds":3903,"nanos":0}`},
+		{arg: Duration(-1500 * time.Millisecond), expected: `{"seconds":-1,"nanos":-500000000}`},
+		{arg: 0, expected: `{"seconds":0,"nanos":0}`},
+		{arg: math.MaxInt64, expected: `{"seconds":9223372036,"nanos":854775807}`},
+		{arg: math.MinInt64, expected: `{"seconds":-9223372036,"nanos":-854775808}`},
+	}
+	for i, tt := range tbl {
+		b, err := tt.arg.MarshalJSON()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, string(b), "case #%d", i)
+
+		var d Duration
+		require.NoError(t, d.UnmarshalJSON(b), "case #%d", i)
+		assert.Equal(t, tt.arg, d, "case #%d", i)
+	}
+
+	SetDurationJSONFormat(DurationJSONISO8601)
+	tbl = []struct {
+		arg      Duration
+		expected string
+	}{
+		{arg: Duration(time.Hour + 5*time.Minute + 3*time.Second), expected: `"PT1H5M3S"`},
+		{arg: Duration(26 * time.Hour), expected: `"PT26H"`},
+		{arg: Duration(-1500 * time.Millisecond), expected: `"-PT1.5S"`},
+		{arg: 0, expected: `"PT0S"`},
+		{arg: math.MaxInt64, expected: `"PT2562047H47M16.854775807S"`},
+	}
+	for i, tt := range tbl {
+		b, err := tt.arg.MarshalJSON()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, string(b), "case #%d", i)
+
+		var d Duration
+		require.NoError(t, d.UnmarshalJSON(b), "case #%d", i)
+		assert.Equal(t, tt.arg, d, "case #%d", i)
+	}
+
+	SetDurationJSONFormat(DurationJSONString)
+	b, err := Duration(time.Hour + 5*time.Minute + 3*time.Second).MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"1h5m3s"`, string(b))
+}
+
+func TestUnknownFormatError_Error(t *testing.T) {
+	ue := UnknownFormatError{
+		Errors: []error{
+			errors.New("one"),
+			errors.New("two"),
+			errors.New("three"),
+		},
+		Layouts: []string{
+			ISO8601Clock,
+			ISO8601ClockMicro,
+			"some unknown layout",
+		},
+		Val: "123456",
+	}
+	assert.EqualError(t, &ue,
+		"timetype: failed to parse \"123456\" in layouts: [\"15:04:05\", \"15:04:05.000000\", \"some unknown layout\"]")
+}
+
+func TestParseDuration(t *testing.T) {
+	tbl := []struct {
+		arg      string
+		expected Duration
+		err      string
+	}{
+		{arg: "1h5m3s", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: "1.5s", expected: Duration(1500 * time.Millisecond)},
+		{arg: "-90m", expected: Duration(-90 * time.Minute)},
+		{arg: "300ms", expected: Duration(300 * time.Millisecond)},
+		{arg: "0", expected: Duration(0)},
+		{arg: "3903000000000", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: "-15", expected: Duration(-15)},
+		{arg: "2d", expected: Duration(48 * time.Hour)},
+		{arg: "1w", expected: Duration(168 * time.Hour)},
+		{arg: "1w2d", expected: Duration(9 * 24 * time.Hour)},
+		{arg: "1.5d", expected: Duration(36 * time.Hour)},
+		{arg: "1d12h30m", expected: Duration(36*time.Hour + 30*time.Minute)},
+		{arg: "-1w2d3h", expected: Duration(-219 * time.Hour)},
+		{arg: "+2d", expected: Duration(48 * time.Hour)},
+		{arg: "PT1H5M3S", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: "P1W", expected: Duration(168 * time.Hour)},
+		{arg: "1y", err: "timetype: ambiguous duration unit"},
+		{arg: "2mo", err: "timetype: ambiguous duration unit"},
+		{arg: "1y2d", err: "timetype: ambiguous duration unit"},
+		{arg: "P1M", err: "timetype: ambiguous duration unit"},
+		{arg: "", err: "timetype: invalid duration"},
+		{arg: "99999999w", err: "timetype: invalid duration"},
+		{arg: "15000w2562047h", err: "timetype: invalid duration"},
+		{arg: "1hour", err: "timetype: parse duration: time: unknown unit \"hour\" in duration \"1hour\""},
+		{arg: "2dd", err: "timetype: parse duration: time: unknown unit \"dd\" in duration \"2dd\""},
+		{arg: "1..5d", err: "timetype: invalid duration"},
+		{arg: "d", err: "timetype: parse duration: time: invalid duration \"d\""},
+		{arg: "2d1x", err: "timetype: parse duration: \"2d1x\": time: unknown unit \"x\" in duration \"1x\""},
+		{arg: "-1w3h2z", err: "timetype: parse duration: \"-1w3h2z\": time: unknown unit \"z\" in duration \"3h2z\""},
+	}
+	for i, tt := range tbl {
+		d, err := ParseDuration(tt.arg)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, "case #%d", i)
+			continue
+		}
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, d, "case #%d", i)
+	}
+
+	_, err := ParseDuration("2d1x")
+	assert.IsType(t, &errExternal{}, err)
+	_, err = ParseDuration("1y")
+	assert.Equal(t, ErrAmbiguousUnit, err)
+}
+
+func TestDuration_Scan(t *testing.T) {
+	tbl := []struct {
+		arg      interface{}
+		expected Duration
+		err      string
+	}{
+		{
+			arg:      nil,
+			expected: Duration(0),
+		},
+		{
+			arg:      5 * time.Minute,
+			expected: Duration(5 * time.Minute),
+		},
+		{
+			arg:      float64(10*time.Second + 1*time.Microsecond),
+			expected: Duration(10*time.Second + 1*time.Microsecond),
+		},
+		{
+			arg:      1.9,
+			expected: Duration(2),
+		},
+		{
+			arg:      -1.5,
+			expected: Duration(-2),
+		},
+		{
+			arg:      123456789012.7,
+			expected: Duration(123456789013),
+		},
+		{
+			arg:      float64(1 << 62),
+			expected: Duration(1 << 62),
+		},
+		{
+			arg:      float64(200 * 24 * time.Hour),
+			expected: Duration(200 * 24 * time.Hour),
+		},
+		{
+			arg:      1e19,
+			expected: Duration(0),
+			err:      "timetype: invalid duration",
+		},
+		{
+			arg:      math.NaN(),
+			expected: Duration(0),
+			err:      "timetype: invalid duration",
+		},
+		{
+			arg:      int64(32 * time.Hour),
+			expected: Duration(32 * time.Hour),
+		},
+		{
+			arg:      time.Duration(32 * time.Hour),
+			expected: Duration(32 * time.Hour),
+		},
+		{
+			arg:      `"5h3m2s"`,
+			expected: Duration(5*time.Hour + 3*time.Minute + 2*time.Second),
+		},
+		{
+			arg:      []byte(`"2h3m"`),
+			expected: Duration(2*time.Hour + 3*time.Minute),
+		},
+		{
+			arg:      `5h3m2s`,
+			expected: Duration(5*time.Hour + 3*time.Minute + 2*time.Second),
+		},
+		{
+			arg:      []byte(`2h3m`),
+			expected: Duration(2*time.Hour + 3*time.Minute),
+		},
+		{
+			arg:      `3903000000000`,
+			expected: Duration(time.Hour + 5*time.Minute + 3*time.Second),
+		},
+		{
+			arg:      `"`,
+			expected: Duration(0),
+			err:      "timetype: scan duration: time: invalid duration \"\\\"\"",
+		},
+		{
+			arg:      `""`,
+			expected: Duration(0),
+			err:      "timetype: invalid duration",
+		},
+		{
+			arg: 'c',
+			err: "timetype: invalid duration",
+		},
+	}
+	for i, tt := range tbl {
+		var d Duration
+		err := d.Scan(tt.arg)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, "case #%d", i)
+		} else {
+			assert.NoError(t, err, "case #%d", i)
+		}
+		assert.Equal(t, tt.expected, d, "case #%d", i)
+	}
+}
+
+func TestDuration_Value(t *testing.T) {
+	tbl := []struct {
+		arg      Duration
+		expected driver.Value
+	}{
+		{
+			arg:      Duration(2*time.Hour + 3*time.Minute),
+			expected: int64(2*time.Hour + 3*time.Minute),
+		},
+		{
+			arg:      Duration(5*time.Hour + 3*time.Minute + 2*time.Second),
+			expected: int64(5*time.Hour + 3*time.Minute + 2*time.Second),
+		},
+		{
+			arg:      Duration(1 * time.Second),
+			expected: int64(1 * time.Second),
+		},
+		{
+			arg:      Duration(1 * time.Millisecond),
+			expected: int64(1 * time.Millisecond),
+		},
+		{
+			arg:      Duration(1 * time.Nanosecond),
+			expected: int64(1 * time.Nanosecond),
+		},
+	}
+
+	for i, tt := range tbl {
+		actual, err := tt.arg.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, actual, "case #%d", i)
+	}
+}
+
+func TestStringDuration_Value(t *testing.T) {
+	tbl := []struct {
+		arg      StringDuration
+		expected driver.Value
+	}{
+		{arg: StringDuration(2*time.Hour + 3*time.Minute), expected: "2h3m0s"},
+		{arg: StringDuration(time.Hour + 5*time.Minute + 3*time.Second), expected: "1h5m3s"},
+		{arg: StringDuration(1500 * time.Millisecond), expected: "1.5s"},
+		{arg: StringDuration(0), expected: "0s"},
+	}
+
+	for i, tt := range tbl {
+		actual, err := tt.arg.Value()
+		assert.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, actual, "case #%d", i)
+
+		var d StringDuration
+		require.NoError(t, d.Scan(actual), "case #%d", i)
+		assert.Equal(t, tt.arg, d, "case #%d", i)
+	}
+}
+
+func TestStringDuration_Scan(t *testing.T) {
+	tbl := []struct {
+		arg      interface{}
+		expected StringDuration
+		err      string
+	}{
+		{arg: nil, expected: StringDuration(0)},
+		{arg: "0s", expected: StringDuration(0)},
+		{arg: "1h5m3s", expected: StringDuration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: []byte("2h3m"), expected: StringDuration(2*time.Hour + 3*time.Minute)},
+		{arg: int64(32 * time.Hour), expected: StringDuration(32 * time.Hour)},
+		{arg: "", err: "timetype: invalid duration"},
+		{arg: 'c', err: "timetype: invalid duration"},
+	}
+	for i, tt := range tbl {
+		var d StringDuration
+		err := d.Scan(tt.arg)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, "case #%d", i)
+		} else {
+			assert.NoError(t, err, "case #%d", i)
+		}
+		assert.Equal(t, tt.expected, d, "case #%d", i)
+	}
+}
+
+func TestDuration_IsZero(t *testing.T) {
+	assert.True(t, Duration(0).IsZero())
+	assert.False(t, Duration(time.Nanosecond).IsZero())
+	assert.False(t, Duration(-time.Hour).IsZero())
+
+	var d Duration
+	require.NoError(t, d.Scan(nil))
+	assert.True(t, d.IsZero())
+}
+
+func TestDuration_Valid(t *testing.T) {
+	tbl := []struct {
+		arg      Duration
+		expected bool
+	}{
+		{arg: Duration(time.Hour + 5*time.Minute), expected: true},
+		{arg: 0, expected: true},
+		{arg: math.MaxInt64 - 1, expected: true},
+		{arg: math.MinInt64 + 1, expected: true},
+		{arg: math.MaxInt64, expected: false},
+		{arg: math.MinInt64, expected: false},
+		{arg: Duration(time.Date(2300, time.January, 1, 0, 0, 0, 0, time.UTC).Sub(time.Time{})), expected: false},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.Valid(), "case #%d", i)
+		v, err := tt.arg.Value()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, int64(tt.arg), v, "case #%d", i)
+	}
+}
+
+func TestDuration_Days(t *testing.T) {
+	tbl := []struct {
+		arg                   Duration
+		days, weeks           float64
+		wholeDays, wholeWeeks int64
+	}{
+		{arg: 0, days: 0, weeks: 0, wholeDays: 0, wholeWeeks: 0},
+		{arg: Duration(48 * time.Hour), days: 2, weeks: 2.0 / 7, wholeDays: 2, wholeWeeks: 0},
+		{arg: Duration(36 * time.Hour), days: 1.5, weeks: 1.5 / 7, wholeDays: 1, wholeWeeks: 0},
+		{arg: Duration(14 * 24 * time.Hour), days: 14, weeks: 2, wholeDays: 14, wholeWeeks: 2},
+		{arg: Duration(10 * 24 * time.Hour), days: 10, weeks: 10.0 / 7, wholeDays: 10, wholeWeeks: 1},
+		{arg: Duration(6 * time.Hour), days: 0.25, weeks: 0.25 / 7, wholeDays: 0, wholeWeeks: 0},
+		{arg: Duration(-36 * time.Hour), days: -1.5, weeks: -1.5 / 7, wholeDays: -2, wholeWeeks: -1},
+		{arg: Duration(-48 * time.Hour), days: -2, weeks: -2.0 / 7, wholeDays: -2, wholeWeeks: -1},
+		{arg: Duration(-10 * 24 * time.Hour), days: -10, weeks: -10.0 / 7, wholeDays: -10, wholeWeeks: -2},
+		{arg: Duration(-14 * 24 * time.Hour), days: -14, weeks: -2, wholeDays: -14, wholeWeeks: -2},
+	}
+	for i, tt := range tbl {
+		assert.InDelta(t, tt.days, tt.arg.Days(), 1e-12, "case #%d", i)
+		assert.InDelta(t, tt.weeks, tt.arg.Weeks(), 1e-12, "case #%d", i)
+		assert.InDelta(t, time.Duration(tt.arg).Hours()/24, tt.arg.Days(), 1e-12, "case #%d", i)
+		assert.Equal(t, tt.wholeDays, tt.arg.WholeDays(), "case #%d", i)
+		assert.Equal(t, tt.wholeWeeks, tt.arg.WholeWeeks(), "case #%d", i)
+	}
+
+	assert.Equal(t, int64(106751), Duration(math.MaxInt64).WholeDays())
+	assert.Equal(t, int64(-106752), Duration(math.MinInt64).WholeDays())
+}
+
+func TestDuration_Round(t *testing.T) {
+	d := Duration(time.Hour + 5*time.Minute + 3*time.Second + 500*time.Millisecond)
+	assert.Equal(t, Duration(time.Hour+5*time.Minute+4*time.Second), d.Round(time.Second))
+	assert.Equal(t, Duration(time.Hour+5*time.Minute), d.Round(time.Minute))
+	assert.Equal(t, Duration(-(time.Hour + 5*time.Minute + 4*time.Second)), (-d).Round(time.Second))
+	assert.Equal(t, d, d.Round(0))
+	assert.Equal(t, d, d.Round(-time.Second))
+}
+
+func TestDuration_Truncate(t *testing.T) {
+	d := Duration(time.Hour + 5*time.Minute + 3*time.Second + 500*time.Millisecond)
+	assert.Equal(t, Duration(time.Hour+5*time.Minute), d.Truncate(time.Minute))
+	assert.Equal(t, Duration(time.Hour+5*time.Minute+3*time.Second), d.Truncate(time.Second))
+	assert.Equal(t, Duration(-(time.Hour + 5*time.Minute)), (-d).Truncate(time.Minute))
+	assert.Equal(t, d, d.Truncate(0))
+	assert.Equal(t, d, d.Truncate(-time.Second))
+}
+
+func TestDuration_Clamp(t *testing.T) {
+	tbl := []struct {
+		arg, min, max Duration
+		expected      Duration
+	}{
+		{arg: Duration(time.Second), min: Duration(time.Minute), max: Duration(time.Hour), expected: Duration(time.Minute)},
+		{arg: Duration(5 * time.Minute), min: Duration(time.Minute), max: Duration(time.Hour), expected: Duration(5 * time.Minute)},
+		{arg: Duration(time.Minute), min: Duration(time.Minute), max: Duration(time.Hour), expected: Duration(time.Minute)},
+		{arg: Duration(time.Hour), min: Duration(time.Minute), max: Duration(time.Hour), expected: Duration(time.Hour)},
+		{arg: Duration(2 * time.Hour), min: Duration(time.Minute), max: Duration(time.Hour), expected: Duration(time.Hour)},
+		{arg: Duration(-time.Hour), min: Duration(-time.Minute), max: 0, expected: Duration(-time.Minute)},
+		{arg: Duration(30 * time.Minute), min: Duration(time.Hour), max: Duration(time.Minute), expected: Duration(time.Hour)},
+		{arg: Duration(2 * time.Hour), min: Duration(time.Hour), max: Duration(time.Minute), expected: Duration(time.Hour)},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.Clamp(tt.min, tt.max), "case #%d", i)
+	}
+}
+
+func TestDuration_HumanString(t *testing.T) {
+	tbl := []struct {
+		arg      Duration
+		expected string
+	}{
+		{arg: 0, expected: "0 seconds"},
+		{arg: Duration(999 * time.Millisecond), expected: "0 seconds"},
+		{arg: Duration(time.Second), expected: "1 second"},
+		{arg: Duration(2 * time.Minute), expected: "2 minutes"},
+		{arg: Duration(time.Hour), expected: "1 hour"},
+		{arg: Duration(time.Hour + 5*time.Minute + 3*time.Second), expected: "1 hour 5 minutes 3 seconds"},
+		{arg: Duration(49*time.Hour + time.Second + 500*time.Millisecond), expected: "2 days 1 hour 1 second"},
+		{arg: Duration(-(time.Minute + time.Second)), expected: "negative 1 minute 1 second"},
+		{arg: Duration(-24 * time.Hour), expected: "negative 1 day"},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.HumanString(), "case #%d", i)
+	}
+}
+
+func TestDuration_MarshalJSON(t *testing.T) {
+	bytes, err := Duration(time.Hour + 5*time.Minute + 3*time.Second).MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`"1h5m3s"`), bytes)
+}
+
+func TestDuration_MarshalText(t *testing.T) {
+	b, err := Duration(time.Hour + 5*time.Minute + 3*time.Second).MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`1h5m3s`), b)
+}
+
+func TestDuration_UnmarshalText(t *testing.T) {
+	tbl := []struct {
+		arg      string
+		expected Duration
+		err      error
+	}{
+		{arg: "1h5m3s", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: "3903000000000", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: "-1500ms", expected: Duration(-1500 * time.Millisecond)},
+		{arg: "0", expected: Duration(0)},
+		{arg: "", err: ErrInvalidDuration},
+	}
+	for i, tt := range tbl {
+		var d Duration
+		err := d.UnmarshalText([]byte(tt.arg))
+		if tt.err != nil {
+			assert.Equal(t, tt.err, err, "case #%d", i)
+			continue
+		}
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, d, "case #%d", i)
+
+		// round trip
+		b, err := d.MarshalText()
+		require.NoError(t, err, "case #%d", i)
+		var rt Duration
+		require.NoError(t, rt.UnmarshalText(b), "case #%d", i)
+		assert.Equal(t, d, rt, "case #%d", i)
+	}
+
+	var d Duration
+	err := d.UnmarshalText([]byte(`"1h5m3s"`))
+	require.Error(t, err)
+	assert.IsType(t, &errExternal{}, err, "text shouldn't be quoted")
+}
+
+func TestDuration_UnmarshalText_SameAsParseDuration(t *testing.T) {
+	for i, tt := range []string{
+		"1h5m3s", "-1h1h", "+5m", "1us", "1µs", "1μs", "2d3h", "1w", "1.5h", "1h0.5s",
+		"-9223372036854775808ns", "9223372036854775808ns", "9223372036854775807ns",
+		"106751d23h47m16s854775807ns", "106751d23h47m16s854775808ns", "99999999999999999999s",
+		"1y", "1mo", "1x", "h", "1", "-0", "99999999999999999999", "PT1H", " 1h", "1h ", "1,5h",
+	} {
+		expected, expectedErr := ParseDuration(tt)
+		var d Duration
+		err := d.UnmarshalText([]byte(tt))
+		if expectedErr != nil {
+			require.Error(t, err, "case #%d", i)
+			assert.Equal(t, withErrOp("unmarshal", expectedErr).Error(), err.Error(), "case #%d", i)
+			continue
+		}
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, expected, d, "case #%d", i)
+	}
+
+	var d Duration
+	for i, src := range []interface{}{[]byte(`"1h5m3s"`), []byte(`3903000000000`), []byte(`2d`), `"1h5m3s"`} {
+		allocs := testing.AllocsPerRun(100, func() { _ = d.Scan(src) })
+		assert.Zero(t, allocs, "case #%d", i)
+	}
+}
+
+func TestClock_MarshalJSON(t *testing.T) {
+	bytes, err := Clock(time.Date(0, time.January, 1, 19, 24, 0, 0, time.UTC)).MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`"19:24:00.000000"`), bytes)
+}
+
+func TestClock_UnmarshalJSON_Null(t *testing.T) {
+	var c Clock
+	require.NoError(t, c.UnmarshalJSON([]byte(`null`)))
+	assert.Equal(t, Clock{}, c)
+	assert.True(t, c.IsZero())
+
+	var s struct {
+		C Clock `json:"c"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"c": null}`), &s))
+	assert.True(t, s.C.IsZero())
+
+	s.C = NewUTCClock(19, 24, 0, 0)
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.Equal(t, `{"c":"19:24:00.000000"}`, string(b))
+	s.C = Clock{}
+	require.NoError(t, json.Unmarshal(b, &s))
+	assert.Equal(t, NewUTCClock(19, 24, 0, 0), s.C)
+}
+
+func TestSetNullZeroClock(t *testing.T) {
+	defer SetNullZeroClock(nullZeroClock)
+
+	b, err := Clock{}.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"00:00:00.000000"`, string(b))
+
+	SetNullZeroClock(true)
+	b, err = Clock{}.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `null`, string(b))
+
+	b, err = NewUTCClock(0, 0, 0, 0).MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"00:00:00.000000"`, string(b))
+
+	var c Clock
+	require.NoError(t, c.UnmarshalJSON([]byte(`null`)))
+	assert.Equal(t, Clock{}, c)
+}
+
+func TestClock_MarshalText(t *testing.T) {
+	b, err := NewUTCClock(19, 24, 0, 0).MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`19:24:00.000000`), b)
+
+	b, err = NewUTCClock(7, 5, 3, 120000).MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`07:05:03.000120`), b)
+}
+
+func TestClock_UnmarshalText(t *testing.T) {
+	var c Clock
+	require.NoError(t, c.UnmarshalText([]byte(`19:24:00.000000`)))
+	assert.Equal(t, NewUTCClock(19, 24, 0, 0), c)
+
+	require.NoError(t, c.UnmarshalText([]byte(`7:24 PM`)))
+	assert.Equal(t, NewUTCClock(19, 24, 0, 0), c)
+
+	// round trip
+	expected := NewUTCClock(7, 5, 3, 120000)
+	b, err := expected.MarshalText()
+	require.NoError(t, err)
+	require.NoError(t, c.UnmarshalText(b))
+	assert.Equal(t, expected, c)
+
+	// errors
+	err = c.UnmarshalText([]byte(`"19:24:00.000000"`))
+	require.Error(t, err)
+	assert.IsType(t, &UnknownFormatError{}, err, "text shouldn't be quoted")
+}
+
+func TestClock_Format(t *testing.T) {
+	c := NewUTCClock(19, 24, 5, 123456789)
+	assert.Equal(t, "19:24:05", c.Format(ISO8601Clock))
+	assert.Equal(t, "19:24:05.123456", c.Format(ISO8601ClockMicro))
+	assert.Equal(t, "19:24:05.123456789", c.Format(ISO8601ClockNano))
+
+	tbl := []struct {
+		arg      Clock
+		layout   string
+		expected string
+	}{
+		{arg: c, layout: "3:04 PM", expected: "7:24 PM"},
+		{arg: c, layout: "15:04", expected: "19:24"},
+		{arg: c, layout: "15:04:05.000", expected: "19:24:05.123"},
+		{arg: c, layout: TwelveHourClockSeconds, expected: "7:24:05 PM"},
+		{arg: NewUTCClock(0, 5, 0, 0), layout: "3:04 PM", expected: "12:05 AM"},
+		{arg: NewUTCClock(12, 0, 0, 0), layout: "03:04pm", expected: "12:00pm"},
+		{arg: NewClock(9, 30, 0, 0, time.FixedZone("", 3*60*60)), layout: "15:04 -07:00", expected: "09:30 +03:00"},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.Format(tt.layout), "case #%d", i)
+	}
+}
+
+func TestRegisterClockLayout(t *testing.T) {
+	defer func(layouts []string) { clockLayouts = layouts }(clockLayouts)
+
+	_, err := ParseClock("19h24m")
+	require.Error(t, err)
+
+	RegisterClockLayout("15h04m")
+	RegisterClockLayout("15h04m")
+	RegisterClockLayout(ISO8601Clock)
+	assert.Equal(t, []string{ISO8601Clock, ISO8601ClockMicro, ISO8601ClockNano, ISO8601ClockZone,
+		ISO8601ClockMicroZone, TwelveHourClock, TwelveHourClockSeconds, TimetzClock, TimetzClockMicro,
+		ISO8601ClockShort, "15h04m"}, ClockLayouts())
+
+	c, err := ParseClock("19h24m")
+	require.NoError(t, err)
+	assert.Equal(t, NewUTCClock(19, 24, 0, 0), c)
+
+	require.NoError(t, c.UnmarshalJSON([]byte(`"07h05m"`)))
+	assert.Equal(t, NewUTCClock(7, 5, 0, 0), c)
+
+	require.NoError(t, c.Scan([]byte("08h15m")))
+	assert.Equal(t, NewUTCClock(8, 15, 0, 0), c)
+
+	_, err = ParseClock("abacaba")
+	assert.EqualError(t, err, "timetype: failed to parse \"abacaba\" in layouts: "+
+		"[\"15:04:05\", \"15:04:05.000000\", \"15:04:05.000000000\", \"15:04:05Z07:00\", "+
+		"\"15:04:05.000000Z07:00\", \"3:04 PM\", \"3:04:05 PM\", \"15:04:05-07\", \"15:04:05.000000-07:00\", \"15:04\", \"15h04m\"]")
+
+	layouts := ClockLayouts()
+	layouts[0] = "modified"
+	assert.Equal(t, ISO8601Clock, ClockLayouts()[0])
+}
+
+func TestClock_FormatLayouts(t *testing.T) {
+	c := NewClock(19, 24, 5, 123456789, time.FixedZone("", 2*60*60))
+	tbl := []struct {
+		layout   string
+		expected string
+	}{
+		{layout: ISO8601Clock, expected: "19:24:05"},
+		{layout: ISO8601ClockMicro, expected: "19:24:05.123456"},
+		{layout: ISO8601ClockNano, expected: "19:24:05.123456789"},
+		{layout: ISO8601ClockZone, expected: "19:24:05+02:00"},
+		{layout: ISO8601ClockMicroZone, expected: "19:24:05.123456+02:00"},
+		{layout: TwelveHourClock, expected: "7:24 PM"},
+		{layout: TwelveHourClockSeconds, expected: "7:24:05 PM"},
+		{layout: ISO8601ClockShort, expected: "19:24"},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, c.Format(tt.layout), "case #%d", i)
+	}
+}
+
+func TestDefaultClockLayout(t *testing.T) {
+	defer SetDefaultClockLayout(defaultClockLayout)
+
+	assert.Equal(t, ISO8601ClockMicro, DefaultClockLayout())
+	SetDefaultClockLayout(ISO8601Clock)
+	assert.Equal(t, ISO8601Clock, DefaultClockLayout())
+}
+
+func TestSetDefaultClockLayout(t *testing.T) {
+	defer SetDefaultClockLayout(defaultClockLayout)
+
+	c := NewUTCClock(19, 24, 5, 123456789)
+	for i, layout := range []string{ISO8601Clock, ISO8601ClockNano} {
+		SetDefaultClockLayout(layout)
+		expected := c.Format(layout)
+
+		b, err := c.MarshalJSON()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, []byte(`"`+expected+`"`), b, "case #%d", i)
+
+		b, err = c.MarshalText()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, []byte(expected), b, "case #%d", i)
+
+		v, err := c.Value()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, driver.Value(expected), v, "case #%d", i)
+
+		// values are still parsed back
+		var parsed Clock
+		require.NoError(t, parsed.Scan(v), "case #%d", i)
+		assert.Equal(t, expected, parsed.Format(layout), "case #%d", i)
+	}
+
+	SetDefaultClockLayout(ISO8601Clock)
+	var parsed Clock
+	require.NoError(t, parsed.UnmarshalJSON([]byte(`"19:24:05.123456789"`)))
+	assert.Equal(t, c, parsed, "parsing doesn't depend on the output layout")
+}
+
+func TestClock_UnmarshalJSON_Zone(t *testing.T) {
+	tbl := []struct {
+		arg    string
+		clock  Clock
+		offset int
+		out    string
+	}{
+		{
+			arg:    `"19:24:00Z"`,
+			clock:  NewUTCClock(19, 24, 0, 0),
+			offset: 0,
+			out:    "19:24:00.000000Z",
+		},
+		{
+			arg:    `"19:24:00+02:00"`,
+			clock:  NewClock(19, 24, 0, 0, time.FixedZone("", 2*60*60)),
+			offset: 2 * 60 * 60,
+			out:    "19:24:00.000000+02:00",
+		},
+		{
+			arg:    `"19:24:00.000500-05:00"`,
+			clock:  NewClock(19, 24, 0, 500000, time.FixedZone("", -5*60*60)),
+			offset: -5 * 60 * 60,
+			out:    "19:24:00.000500-05:00",
+		},
+	}
+	for i, tt := range tbl {
+		var c Clock
+		require.NoError(t, c.UnmarshalJSON([]byte(tt.arg)), "case #%d", i)
+		assert.True(t, tt.clock.Equal(c), "case #%d", i)
+		assert.Equal(t, tt.clock.Format(ISO8601ClockMicro), c.Format(ISO8601ClockMicro), "case #%d", i)
+		_, offset := time.Time(c).Zone()
+		assert.Equal(t, tt.offset, offset, "case #%d", i)
+		assert.Equal(t, tt.out, c.Format(ISO8601ClockMicroZone), "case #%d", i)
+	}
+}
+
+func TestClock_NanoRoundTrip(t *testing.T) {
+	defer SetDefaultClockLayout(defaultClockLayout)
+	SetDefaultClockLayout(ISO8601ClockNano)
+
+	expected := NewUTCClock(19, 24, 5, 123456789)
+
+	b, err := expected.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`"19:24:05.123456789"`), b)
+	var c Clock
+	require.NoError(t, c.UnmarshalJSON(b))
+	assert.Equal(t, expected, c)
+
+	v, err := expected.Value()
+	require.NoError(t, err)
+	assert.Equal(t, driver.Value(`19:24:05.123456789`), v)
+	c = Clock{}
+	require.NoError(t, c.Scan(v))
+	assert.Equal(t, expected, c)
+}
+
+func TestClock_ScanKeepsInstant(t *testing.T) {
+	plus2 := time.FixedZone("", 2*60*60)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	var fromString Clock
+	require.NoError(t, fromString.Scan("19:24:00+02:00"))
+	for i, tt := range []time.Time{
+		time.Date(2023, time.May, 1, 19, 24, 0, 0, plus2),
+		time.Date(2023, time.May, 1, 19, 24, 0, 0, berlin), // CEST, +02:00 in May
+		time.Date(2023, time.May, 1, 17, 24, 0, 0, time.UTC),
+	} {
+		var c Clock
+		require.NoError(t, c.Scan(tt), "case #%d", i)
+		assert.True(t, fromString.Equal(c), "case #%d", i)
+		assert.Equal(t, tt.Hour(), c.Hour(), "case #%d", i)
+		_, offset := tt.Zone()
+		_, scannedOffset := time.Time(c).Zone()
+		assert.Equal(t, offset, scannedOffset, "case #%d", i)
+	}
+}
+
+func TestClock_Scan(t *testing.T) {
+	tbl := []struct {
+		arg      interface{}
+		expected Clock
+		err      string
+	}{
+		{
+			arg:      nil,
+			expected: Clock(time.Time{}),
+		},
+		{
+			arg:      time.Date(0, time.January, 1, 2, 19, 30, 0, time.UTC),
+			expected: Clock(time.Date(0, time.January, 1, 2, 19, 30, 0, time.UTC)),
+		},
+		{
+			arg:      sql.NullTime{Time: time.Date(0, time.January, 1, 2, 19, 30, 0, time.UTC), Valid: true},
+			expected: Clock(time.Date(0, time.January, 1, 2, 19, 30, 0, time.UTC)),
+		},
+		{
+			arg:      sql.NullTime{Time: time.Date(0, time.January, 1, 2, 19, 30, 0, time.UTC)},
+			expected: Clock{},
+		},
+		{
+			arg:      &time.Time{},
+			expected: Clock(time.Time{}),
+		},
+		{
+			arg:      time.Date(2023, time.May, 1, 2, 19, 30, 0, time.FixedZone("", 3*60*60)),
+			expected: NewClock(2, 19, 30, 0, time.FixedZone("", 3*60*60)),
+		},
+		{
+			arg:      sql.NullTime{Time: time.Date(2023, time.May, 1, 19, 24, 0, 0, time.FixedZone("", -7*60*60)), Valid: true},
+			expected: NewClock(19, 24, 0, 0, time.FixedZone("", -7*60*60)),
+		},
+		{
+			arg: func() *time.Time {
+				t := time.Date(2023, time.May, 1, 7, 5, 0, 0, time.FixedZone("", 2*60*60))
+				return &t
+			}(),
+			expected: NewClock(7, 5, 0, 0, time.FixedZone("", 2*60*60)),
+		},
+		{
+			arg:      time.Date(2023, time.May, 1, 19, 24, 0, 500, time.UTC),
+			expected: NewUTCClock(19, 24, 0, 500),
+		},
+		{
+			arg:      sql.NullTime{Time: time.Date(1970, time.January, 1, 23, 59, 59, 0, time.UTC), Valid: true},
+			expected: NewUTCClock(23, 59, 59, 0),
+		},
+		{
+			arg:      func() *time.Time { t := time.Date(0, time.January, 1, 7, 5, 0, 0, time.UTC); return &t }(),
+			expected: Clock(time.Date(0, time.January, 1, 7, 5, 0, 0, time.UTC)),
+		},
+		{
+			arg:      (*time.Time)(nil),
+			expected: Clock{},
+		},
+		{
+			arg:      `19:24:00.000000`,
+			expected: Clock(time.Date(0, time.January, 1, 19, 24, 0, 0, time.UTC)),
+		},
+		{
+			arg:      []byte(`2:21:55.000000`),
+			expected: Clock(time.Date(0, time.January, 1, 2, 21, 55, 0, time.UTC)),
+		},
+		{
+			arg:      `7:24 PM`,
+			expected: Clock(time.Date(0, time.January, 1, 19, 24, 0, 0, time.UTC)),
+		},
+		{
+			arg:      []byte(`11:05 AM`),
+			expected: Clock(time.Date(0, time.January, 1, 11, 5, 0, 0, time.UTC)),
+		},
+		{
+			arg:      2567,
+			expected: NewUTCClock(0, 42, 47, 0),
+		},
+		{
+			arg:      int64(0),
+			expected: NewUTCClock(0, 0, 0, 0),
+		},
+		{
+			arg:      int64(70200),
+			expected: NewUTCClock(19, 30, 0, 0),
+		},
+		{
+			arg:      86399,
+			expected: NewUTCClock(23, 59, 59, 0),
+		},
+		{
+			arg:      int64(90000),
+			expected: Clock{},
+			err:      "timetype: invalid clock",
+		},
+		{
+			arg:      86400,
+			expected: Clock{},
+			err:      "timetype: invalid clock",
+		},
+		{
+			arg:      int64(-1),
+			expected: Clock{},
+			err:      "timetype: invalid clock",
+		},
+		{
+			arg:      0.0,
+			expected: NewUTCClock(0, 0, 0, 0),
+		},
+		{
+			arg:      70200.5,
+			expected: NewUTCClock(19, 30, 0, 500000000),
+		},
+		{
+			arg:      86399.999999999,
+			expected: NewUTCClock(23, 59, 59, 999999999),
+		},
+		{
+			arg:      90000.0,
+			expected: Clock{},
+			err:      "timetype: invalid clock",
+		},
+		{
+			arg:      -0.5,
+			expected: Clock{},
+			err:      "timetype: invalid clock",
+		},
+		{
+			arg:      math.NaN(),
+			expected: Clock{},
+			err:      "timetype: invalid clock",
+		},
+		{
+			arg:      float32(2.5),
 			expected: Clock{},
 			err:      "timetype: invalid clock",
 		},
 		{
 			arg:      "abacaba",
 			expected: Clock{},
-			err:      "timetype: failed to parse \"abacaba\" in layouts: [\"15:04:05\", \"15:04:05.000000\"]",
+			err:      "timetype: failed to parse \"abacaba\" in layouts: [\"15:04:05\", \"15:04:05.000000\", \"15:04:05.000000000\", \"15:04:05Z07:00\", \"15:04:05.000000Z07:00\", \"3:04 PM\", \"3:04:05 PM\", \"15:04:05-07\", \"15:04:05.000000-07:00\", \"15:04\"]",
 		},
 		{
 			arg:      []byte("abacaba"),
 			expected: Clock{},
-			err:      "timetype: failed to parse \"abacaba\" in layouts: [\"15:04:05\", \"15:04:05.000000\"]",
+			err:      "timetype: failed to parse \"abacaba\" in layouts: [\"15:04:05\", \"15:04:05.000000\", \"15:04:05.000000000\", \"15:04:05Z07:00\", \"15:04:05.000000Z07:00\", \"3:04 PM\", \"3:04:05 PM\", \"15:04:05-07\", \"15:04:05.000000-07:00\", \"15:04\"]",
 		},
 	}
 
@@ -267,3 +1878,566 @@ func TestClock_Value(t *testing.T) {
 		assert.Equal(t, tt.expected, actual, "case #%d", i)
 	}
 }
+
+func TestClock_ScanTimetz(t *testing.T) {
+	tbl := []struct {
+		arg      interface{}
+		expected Clock
+	}{
+		{arg: "19:24:00+02", expected: NewClock(19, 24, 0, 0, time.FixedZone("", 2*60*60))},
+		{arg: []byte("19:24:00.5-07"), expected: NewClock(19, 24, 0, 500000000, time.FixedZone("", -7*60*60))},
+		{arg: "19:24:00.000000-07:00", expected: NewClock(19, 24, 0, 0, time.FixedZone("", -7*60*60))},
+	}
+	for i, tt := range tbl {
+		var c Clock
+		require.NoError(t, c.Scan(tt.arg), "case #%d", i)
+		assert.True(t, tt.expected.Equal(c), "case #%d", i)
+		_, expectedOffset := time.Time(tt.expected).Zone()
+		_, offset := time.Time(c).Zone()
+		assert.Equal(t, expectedOffset, offset, "case #%d", i)
+	}
+}
+
+func TestClock_ValueWithZone(t *testing.T) {
+	tbl := []struct {
+		arg      Clock
+		expected driver.Value
+	}{
+		{arg: NewClock(19, 24, 0, 0, time.FixedZone("", 2*60*60)), expected: "19:24:00.000000+02:00"},
+		{arg: NewClock(7, 5, 3, 123456000, time.FixedZone("", -7*60*60)), expected: "07:05:03.123456-07:00"},
+		{arg: NewUTCClock(19, 24, 0, 0), expected: "19:24:00.000000+00:00"},
+	}
+	for i, tt := range tbl {
+		v, err := tt.arg.ValueWithZone()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, v, "case #%d", i)
+
+		var c Clock
+		require.NoError(t, c.Scan(v), "case #%d", i)
+		assert.True(t, tt.arg.Equal(c), "case #%d", i)
+	}
+}
+
+func TestClock_ValueSeconds(t *testing.T) {
+	tbl := []struct {
+		arg      Clock
+		expected driver.Value
+	}{
+		{arg: NewUTCClock(0, 0, 0, 0), expected: int64(0)},
+		{arg: NewUTCClock(19, 30, 0, 0), expected: int64(70200)},
+		{arg: NewUTCClock(23, 59, 59, 999999999), expected: int64(86399)},
+		{arg: NewUTCClock(2, 21, 55, 500000000), expected: int64(8515)},
+	}
+
+	for i, tt := range tbl {
+		actual, err := tt.arg.ValueSeconds()
+		assert.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, actual, "case #%d", i)
+
+		var c Clock
+		require.NoError(t, c.Scan(actual), "case #%d", i)
+		assert.Equal(t, tt.arg.Truncate(time.Second), c, "case #%d", i)
+	}
+}
+
+func TestClock_AppendFormat(t *testing.T) {
+	tbl := []Clock{
+		NewUTCClock(19, 24, 0, 0),
+		NewUTCClock(0, 0, 0, 0),
+		NewUTCClock(2, 21, 55, 123456000),
+	}
+	for i, tt := range tbl {
+		b, err := tt.MarshalJSON()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, string(b), `"`+string(tt.AppendFormat(nil))+`"`, "case #%d", i)
+		assert.Equal(t, "clock="+tt.Format(ISO8601ClockMicro), string(tt.AppendFormat([]byte("clock="))), "case #%d", i)
+	}
+}
+
+func TestDuration_AppendFormat(t *testing.T) {
+	tbl := []Duration{
+		0,
+		Duration(time.Hour + 5*time.Minute + 3*time.Second),
+		Duration(-1500 * time.Millisecond),
+		Duration(math.MinInt64),
+	}
+	for i, tt := range tbl {
+		b, err := tt.MarshalJSON()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, string(b), `"`+string(tt.AppendFormat(nil))+`"`, "case #%d", i)
+		assert.Equal(t, "d="+time.Duration(tt).String(), string(tt.AppendFormat([]byte("d="))), "case #%d", i)
+	}
+
+	defer SetZeroDurationString(zeroDurationString)
+	SetZeroDurationString("0")
+	assert.Equal(t, "d=0", string(Duration(0).AppendFormat([]byte("d="))))
+}
+
+func BenchmarkClock_MarshalJSON(b *testing.B) {
+	c := NewUTCClock(19, 24, 5, 123456000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = c.MarshalJSON()
+	}
+}
+
+func BenchmarkClock_AppendFormat(b *testing.B) {
+	c := NewUTCClock(19, 24, 5, 123456000)
+	buf := make([]byte, 0, 64)
+	b.Run("Clock.AppendFormat", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf = c.AppendFormat(buf[:0])
+		}
+	})
+	b.Run("time.Time.AppendFormat", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf = time.Time(c).AppendFormat(buf[:0], ISO8601ClockMicro)
+		}
+	})
+}
+
+func BenchmarkDuration_MarshalJSON(b *testing.B) {
+	d := Duration(time.Hour + 5*time.Minute + 3*time.Second)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = d.MarshalJSON()
+	}
+}
+
+func BenchmarkDuration_AppendFormat(b *testing.B) {
+	d := Duration(time.Hour + 5*time.Minute + 3*time.Second)
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = d.AppendFormat(buf[:0])
+	}
+}
+
+func BenchmarkDuration_Scan(b *testing.B) {
+	for _, src := range []interface{}{[]byte(`"1h5m3s"`), []byte(`3903000000000`), `"1h5m3s"`} {
+		b.Run(fmt.Sprintf("%T/%s", src, src), func(b *testing.B) {
+			var d Duration
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = d.Scan(src)
+			}
+		})
+	}
+}
+
+func TestClock_EndOfDay(t *testing.T) {
+	tbl := []struct {
+		arg      string
+		expected Clock
+		err      string
+	}{
+		{arg: "24:00:00", expected: EndOfDay(time.UTC)},
+		{arg: "24:00:00.000000", expected: EndOfDay(time.UTC)},
+		{arg: "24:00:00.000000000", expected: EndOfDay(time.UTC)},
+		{arg: "24:00:00+02:00", expected: EndOfDay(time.FixedZone("", 2*60*60))},
+		{arg: "24:00:01", err: "timetype: invalid clock"},
+		{arg: "24:00:00.000001", err: "timetype: invalid clock"},
+		{arg: "24:30:00", err: "timetype: invalid clock"},
+		{arg: "25:00:00", err: "timetype: failed to parse \"25:00:00\" in layouts: " +
+			"[\"15:04:05\", \"15:04:05.000000\", \"15:04:05.000000000\", \"15:04:05Z07:00\", \"15:04:05.000000Z07:00\", \"3:04 PM\", \"3:04:05 PM\", \"15:04:05-07\", \"15:04:05.000000-07:00\", \"15:04\"]"},
+		{arg: "24:ab", err: "timetype: failed to parse \"24:ab\" in layouts: " +
+			"[\"15:04:05\", \"15:04:05.000000\", \"15:04:05.000000000\", \"15:04:05Z07:00\", \"15:04:05.000000Z07:00\", \"15:04:05-07\", \"15:04:05.000000-07:00\", \"15:04\"]"},
+		{arg: "24:00 AM", err: "timetype: failed to parse \"24:00 AM\" in layouts: " +
+			"[\"15:04:05\", \"15:04:05.000000\", \"15:04:05.000000000\", \"15:04:05Z07:00\", \"15:04:05.000000Z07:00\", \"15:04:05-07\", \"15:04:05.000000-07:00\", \"15:04\"]"},
+		{arg: "24:00 PM", err: "timetype: failed to parse \"24:00 PM\" in layouts: " +
+			"[\"15:04:05\", \"15:04:05.000000\", \"15:04:05.000000000\", \"15:04:05Z07:00\", \"15:04:05.000000Z07:00\", \"15:04:05-07\", \"15:04:05.000000-07:00\", \"15:04\"]"},
+		{arg: "24:00:00 PM", err: "timetype: failed to parse \"24:00:00 PM\" in layouts: " +
+			"[\"15:04:05\", \"15:04:05.000000\", \"15:04:05.000000000\", \"15:04:05Z07:00\", \"15:04:05.000000Z07:00\", \"15:04:05-07\", \"15:04:05.000000-07:00\", \"15:04\"]"},
+	}
+	for i, tt := range tbl {
+		c, err := ParseClock(tt.arg)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, "case #%d", i)
+			continue
+		}
+		require.NoError(t, err, "case #%d", i)
+		assert.True(t, c.IsEndOfDay(), "case #%d", i)
+		assert.Equal(t, tt.expected.String(), c.String(), "case #%d", i)
+		assert.True(t, tt.expected.Equal(c), "case #%d", i)
+	}
+
+	eod := EndOfDay(time.UTC)
+	assert.True(t, eod.IsEndOfDay())
+	assert.False(t, NewUTCClock(0, 0, 0, 0).IsEndOfDay())
+	assert.False(t, Clock{}.IsEndOfDay())
+	assert.Equal(t, "24:00:00 UTC", eod.String())
+	assert.Equal(t, "timetype.EndOfDay(UTC)", eod.GoString())
+	assert.Equal(t, "24:00", eod.Format("15:04"))
+	assert.Equal(t, "12:00 AM", eod.Format(TwelveHourClock))
+	assert.True(t, eod.After(NewUTCClock(23, 59, 59, 999999999)))
+	assert.Equal(t, time.Hour, eod.Sub(NewUTCClock(23, 0, 0, 0)))
+	assert.Equal(t, NewUTCClock(1, 0, 0, 0), eod.Add(time.Hour))
+
+	b, err := eod.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"24:00:00.000000"`, string(b))
+	var c Clock
+	require.NoError(t, c.UnmarshalJSON(b))
+	assert.Equal(t, eod, c)
+
+	v, err := eod.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "24:00:00.000000", v)
+	require.NoError(t, c.Scan(v))
+	assert.Equal(t, eod, c)
+
+	p := Period{Start: NewUTCClock(18, 0, 0, 0), End: eod}
+	assert.True(t, p.Contains(NewUTCClock(23, 59, 0, 0)))
+	assert.False(t, p.Contains(NewUTCClock(0, 0, 0, 0)))
+	assert.Equal(t, 6*time.Hour, p.Duration())
+}
+
+func TestSetStrictClockPrecision(t *testing.T) {
+	defer SetStrictClockPrecision(strictClockPrecision)
+	defer SetDefaultClockLayout(defaultClockLayout)
+
+	nano := NewUTCClock(19, 24, 5, 123456789)
+	micro := NewUTCClock(19, 24, 5, 123456000)
+
+	// lenient mode truncates
+	b, err := nano.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"19:24:05.123456"`, string(b))
+	v, err := nano.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "19:24:05.123456", v)
+
+	SetStrictClockPrecision(true)
+	_, err = nano.MarshalJSON()
+	assert.Equal(t, ErrPrecisionLoss, err)
+	_, err = nano.MarshalText()
+	assert.Equal(t, ErrPrecisionLoss, err)
+	_, err = nano.Value()
+	assert.Equal(t, ErrPrecisionLoss, err)
+	_, err = json.Marshal(struct{ C Clock }{C: nano})
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+	_, err = nano.MarshalYAML()
+	assert.Equal(t, ErrPrecisionLoss, err)
+	_, err = xml.Marshal(nano)
+	assert.Equal(t, ErrPrecisionLoss, err)
+	_, err = nano.MarshalXMLAttr(xml.Name{Local: "c"})
+	assert.Equal(t, ErrPrecisionLoss, err)
+	assert.Equal(t, ErrPrecisionLoss, nano.MarshalGQLContext(context.Background(), &bytes.Buffer{}))
+	_, err = nano.ValueWithZone()
+	assert.Equal(t, ErrPrecisionLoss, err)
+	_, err = micro.ValueSeconds()
+	assert.Equal(t, ErrPrecisionLoss, err)
+	v, err = NewUTCClock(19, 24, 5, 0).ValueSeconds()
+	require.NoError(t, err)
+	assert.Equal(t, int64(69845), v)
+	buf := &bytes.Buffer{}
+	require.NoError(t, micro.MarshalGQLContext(context.Background(), buf))
+	assert.Equal(t, `"19:24:05.123456"`, buf.String())
+
+	b, err = micro.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"19:24:05.123456"`, string(b))
+	b, err = EndOfDay(time.UTC).MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"24:00:00.000000"`, string(b))
+
+	SetDefaultClockLayout(ISO8601ClockNano)
+	b, err = nano.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"19:24:05.123456789"`, string(b))
+
+	SetDefaultClockLayout(ISO8601Clock)
+	_, err = micro.Value()
+	assert.Equal(t, ErrPrecisionLoss, err)
+	v, err = NewUTCClock(19, 24, 5, 0).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "19:24:05", v)
+
+	SetDefaultClockLayout(ISO8601ClockZone)
+	v, err = NewClock(19, 24, 5, 0, time.FixedZone("", 3*60*60)).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "19:24:05+03:00", v)
+}
+
+func TestSetRejectNegativeDuration(t *testing.T) {
+	defer SetRejectNegativeDuration(rejectNegativeDuration)
+
+	neg := Duration(-(time.Hour + 5*time.Minute + 3*time.Second))
+	pos := Duration(time.Hour + 5*time.Minute + 3*time.Second)
+
+	b, err := neg.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"-1h5m3s"`, string(b))
+	v, err := neg.Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(neg), v)
+
+	SetRejectNegativeDuration(true)
+	_, err = neg.MarshalJSON()
+	assert.Equal(t, ErrInvalidDuration, err)
+	_, err = neg.MarshalText()
+	assert.Equal(t, ErrInvalidDuration, err)
+	_, err = neg.Value()
+	assert.Equal(t, ErrInvalidDuration, err)
+	_, err = StringDuration(neg).Value()
+	assert.Equal(t, ErrInvalidDuration, err)
+
+	b, err = pos.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"1h5m3s"`, string(b))
+	v, err = Duration(0).Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), v)
+
+	var d Duration
+	require.NoError(t, d.UnmarshalJSON([]byte(`"-1h5m3s"`)))
+	assert.Equal(t, neg, d)
+}
+
+func TestSetStrictDurationParse(t *testing.T) {
+	defer SetStrictDurationParse(strictDurationParse)
+
+	d, err := ParseDuration("1h1h")
+	require.NoError(t, err)
+	assert.Equal(t, Duration(2*time.Hour), d, "units are summed up by default")
+
+	SetStrictDurationParse(true)
+	tbl := []struct {
+		arg      string
+		expected Duration
+	}{
+		{arg: "1h5m3s", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: "-1w2d3h", expected: Duration(-219 * time.Hour)},
+		{arg: "+1.5h", expected: Duration(90 * time.Minute)},
+		{arg: "1d1h", expected: Duration(25 * time.Hour)},
+		{arg: "300ms5µs", expected: Duration(300*time.Millisecond + 5*time.Microsecond)},
+		{arg: "123", expected: 123},
+		{arg: "PT1H5M3S", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: "2562047h47m16.854775807s", expected: math.MaxInt64},
+		{arg: "-2562047h47m16.854775808s", expected: math.MinInt64},
+	}
+	for i, tt := range tbl {
+		d, err := ParseDuration(tt.arg)
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, d, "case #%d", i)
+	}
+
+	for i, tt := range []string{
+		"1h1h", "1s2m1s", "2d1d", "9999999h", "15251w", "2562047h47m16.854775808s",
+		"99999999999999999999", "1x", "h", "1h5", "-", "1h 5m",
+	} {
+		_, err := ParseDuration(tt)
+		assert.Equal(t, ErrInvalidDuration, err, "case #%d", i)
+	}
+	_, err = ParseDuration("1y")
+	assert.Equal(t, ErrAmbiguousUnit, err)
+
+	d = Duration(time.Second)
+	assert.Equal(t, ErrInvalidDuration, d.UnmarshalJSON([]byte(`"1h1h"`)))
+	assert.Equal(t, ErrInvalidDuration, d.Scan("9999999h"))
+	assert.Equal(t, Duration(time.Second), d)
+	require.NoError(t, d.Scan("1h5m"))
+	assert.Equal(t, Duration(time.Hour+5*time.Minute), d)
+}
+
+func TestDuration_String(t *testing.T) {
+	tbl := []struct {
+		arg      Duration
+		expected string
+	}{
+		{arg: 0, expected: "0s"},
+		{arg: Duration(time.Hour + 5*time.Minute + 3*time.Second), expected: "1h5m3s"},
+		{arg: Duration(-time.Millisecond), expected: "-1ms"},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.String(), "case #%d", i)
+		assert.Equal(t, tt.expected, fmt.Sprint(tt.arg), "case #%d", i)
+	}
+}
+
+func TestSetZeroDurationString(t *testing.T) {
+	defer SetZeroDurationString(zeroDurationString)
+
+	for i, zero := range []string{"0", ""} {
+		SetZeroDurationString(zero)
+		assert.Equal(t, zero, Duration(0).String(), "case #%d", i)
+		assert.Equal(t, "1h5m3s", Duration(time.Hour+5*time.Minute+3*time.Second).String(), "case #%d", i)
+
+		b, err := Duration(0).MarshalJSON()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, strconv.Quote(zero), string(b), "case #%d", i)
+		d := Duration(time.Second)
+		require.NoError(t, d.UnmarshalJSON(b), "case #%d", i)
+		assert.Equal(t, Duration(0), d, "case #%d", i)
+
+		b, err = Duration(0).MarshalText()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, zero, string(b), "case #%d", i)
+		d = Duration(time.Second)
+		require.NoError(t, d.UnmarshalText(b), "case #%d", i)
+		assert.Equal(t, Duration(0), d, "case #%d", i)
+
+		v, err := StringDuration(0).Value()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, zero, v, "case #%d", i)
+	}
+}
+
+func TestDuration_HMS(t *testing.T) {
+	tbl := []struct {
+		arg      Duration
+		expected string
+	}{
+		{arg: 0, expected: "00:00:00"},
+		{arg: Duration(5*time.Minute + 3*time.Second + 500*time.Millisecond), expected: "00:05:03"},
+		{arg: Duration(time.Hour + 5*time.Minute + 3*time.Second), expected: "01:05:03"},
+		{arg: Duration(26*time.Hour + 59*time.Second), expected: "26:00:59"},
+		{arg: Duration(100 * time.Hour), expected: "100:00:00"},
+		{arg: Duration(-(time.Hour + 5*time.Minute + 3*time.Second)), expected: "-01:05:03"},
+		{arg: Duration(-time.Millisecond), expected: "00:00:00"},
+		{arg: math.MinInt64, expected: "-2562047:47:16"},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.HMS(), "case #%d", i)
+	}
+}
+
+func TestDuration_Split(t *testing.T) {
+	tbl := []struct {
+		arg                                  Duration
+		days, hours, minutes, seconds, nanos int
+		neg                                  bool
+	}{
+		{arg: 0},
+		{arg: Duration(3*24*time.Hour + 2*time.Hour + 5*time.Minute + 3*time.Second + 7), days: 3, hours: 2, minutes: 5, seconds: 3, nanos: 7},
+		{arg: Duration(500 * time.Millisecond), nanos: 500000000},
+		{arg: Duration(-(26*time.Hour + 30*time.Second)), days: 1, hours: 2, seconds: 30, neg: true},
+		{arg: Duration(-time.Nanosecond), nanos: 1, neg: true},
+		{arg: math.MinInt64, days: 106751, hours: 23, minutes: 47, seconds: 16, nanos: 854775808, neg: true},
+	}
+	for i, tt := range tbl {
+		days, hours, minutes, seconds, nanos, neg := tt.arg.Split()
+		assert.Equal(t, []int{tt.days, tt.hours, tt.minutes, tt.seconds, tt.nanos},
+			[]int{days, hours, minutes, seconds, nanos}, "case #%d", i)
+		assert.Equal(t, tt.neg, neg, "case #%d", i)
+
+		sum := uint64(days)*uint64(24*time.Hour) + uint64(hours)*uint64(time.Hour) +
+			uint64(minutes)*uint64(time.Minute) + uint64(seconds)*uint64(time.Second) + uint64(nanos)
+		if neg {
+			sum = -sum
+		}
+		assert.Equal(t, tt.arg, Duration(sum), "case #%d", i)
+	}
+}
+
+func TestParseHMS(t *testing.T) {
+	tbl := []struct {
+		arg      string
+		expected Duration
+	}{
+		{arg: "01:05:03", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: "100:00:00", expected: Duration(100 * time.Hour)},
+		{arg: "05:03", expected: Duration(5*time.Minute + 3*time.Second)},
+		{arg: "90:00", expected: Duration(90 * time.Minute)},
+		{arg: "-00:30", expected: Duration(-30 * time.Second)},
+		{arg: "-01:05:03", expected: Duration(-(time.Hour + 5*time.Minute + 3*time.Second))},
+		{arg: "00:00:03.5", expected: Duration(3500 * time.Millisecond)},
+		{arg: "00:03.000000001", expected: Duration(3*time.Second + 1)},
+		{arg: "-2562047:47:16.854775808", expected: math.MinInt64},
+	}
+	for i, tt := range tbl {
+		d, err := ParseHMS(tt.arg)
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, d, "case #%d", i)
+	}
+
+	for i, tt := range []string{
+		"", "1:2:3:4", "05", "01:60:00", "00:00:60", "01:a:03", "01::03", "+01:05:03",
+		"01.5:00:00", "00:03.", "00: 03", "2562047:47:16.854775808",
+	} {
+		_, err := ParseHMS(tt)
+		assert.Equal(t, ErrInvalidDuration, err, "case #%d", i)
+	}
+
+	for i, d := range []Duration{0, Duration(time.Hour + 5*time.Minute + 3*time.Second), Duration(-100 * time.Hour)} {
+		parsed, err := ParseHMS(d.HMS())
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, d, parsed, "case #%d", i)
+	}
+}
+
+func TestDuration_Abs(t *testing.T) {
+	tbl := []struct {
+		arg, expected Duration
+	}{
+		{arg: 0, expected: 0},
+		{arg: Duration(time.Hour), expected: Duration(time.Hour)},
+		{arg: Duration(-time.Hour), expected: Duration(time.Hour)},
+		{arg: Duration(math.MaxInt64), expected: Duration(math.MaxInt64)},
+		{arg: Duration(math.MinInt64), expected: Duration(math.MaxInt64)},
+		{arg: Duration(math.MinInt64 + 1), expected: Duration(math.MaxInt64)},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, tt.arg.Abs(), "case #%d", i)
+	}
+}
+
+func TestClock_MapKey(t *testing.T) {
+	m := map[Clock]string{
+		NewUTCClock(9, 0, 0, 0):   "open",
+		NewUTCClock(19, 24, 5, 0): "close",
+	}
+	b, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, `{"09:00:00.000000":"open","19:24:05.000000":"close"}`, string(b))
+
+	var res map[Clock]string
+	require.NoError(t, json.Unmarshal(b, &res))
+	assert.Equal(t, m, res)
+
+	// keys don't depend on the location of clocks
+	b, err = json.Marshal(map[Clock]string{NewClock(9, 0, 0, 0, time.FixedZone("", 3*60*60)): "open"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"09:00:00.000000":"open"}`, string(b))
+
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	nyClock := NewClock(9, 0, 0, 0, ny)
+	b, err = json.Marshal(map[Clock]string{nyClock: "open"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"09:00:00.000000":"open"}`, string(b))
+	text, err := nyClock.MarshalText()
+	require.NoError(t, err)
+	js, err := nyClock.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"`+string(text)+`"`, string(js))
+	res = nil
+	require.NoError(t, json.Unmarshal(b, &res))
+	assert.Equal(t, map[Clock]string{nyClock.Normalize(): "open"}, res)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"7:24 PM":"close"}`), &res))
+	assert.Equal(t, "close", res[NewUTCClock(19, 24, 0, 0)])
+
+	assert.IsType(t, &UnknownFormatError{}, json.Unmarshal([]byte(`{"abacaba":"close"}`), &res))
+}
+
+func TestDuration_MapKey(t *testing.T) {
+	m := map[Duration]int{
+		Duration(time.Hour + 5*time.Minute + 3*time.Second): 1,
+		Duration(-1500 * time.Millisecond):                  2,
+		0:                                                   3,
+	}
+	b, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, `{"-1.5s":2,"0s":3,"1h5m3s":1}`, string(b))
+
+	var res map[Duration]int
+	require.NoError(t, json.Unmarshal(b, &res))
+	assert.Equal(t, m, res)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"7d":1,"3903000000000":2}`), &res))
+	assert.Equal(t, 1, res[Duration(168*time.Hour)])
+	assert.Equal(t, 2, res[Duration(time.Hour+5*time.Minute+3*time.Second)])
+}
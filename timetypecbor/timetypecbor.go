@@ -0,0 +1,97 @@
+// Package timetypecbor adds CBOR marshaling of fxamacker/cbor to timetype values.
+// It lives apart from timetype to keep the CBOR dependency optional.
+package timetypecbor
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Semior001/timetype"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// durationAsInteger defines whether Duration is marshaled into CBOR as integer
+var durationAsInteger = false
+
+// SetDurationAsInteger sets whether Duration.MarshalCBOR encodes the duration as
+// the integer amount of nanoseconds instead of the string, like "1h5m3s". Both forms
+// are decoded regardless of this setting. As with timetype.SetDefaultClockLayout,
+// it should be called once at the program start.
+func SetDurationAsInteger(enabled bool) {
+	durationAsInteger = enabled
+}
+
+// Clock is timetype.Clock, that is marshaled into CBOR as a text string
+// in the same format as timetype.Clock.MarshalText does
+type Clock struct{ timetype.Clock }
+
+// MarshalCBOR implements cbor.Marshaler and encodes the clock as a text string
+func (h Clock) MarshalCBOR() ([]byte, error) {
+	b, err := h.Clock.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return cbor.Marshal(string(b))
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler and parses the text string in any
+// of the known layouts, as timetype.ParseClock does. CBOR null leaves the clock
+// unchanged.
+func (h *Clock) UnmarshalCBOR(b []byte) error {
+	var v interface{}
+	if err := cbor.Unmarshal(b, &v); err != nil {
+		return fmt.Errorf("timetypecbor: unmarshal clock: %w", err)
+	}
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return h.Clock.UnmarshalText([]byte(val))
+	default:
+		return timetype.ErrInvalidClock
+	}
+}
+
+// Duration is timetype.Duration, that is marshaled into CBOR as a text string,
+// like "1h5m3s", or as the integer amount of nanoseconds, see SetDurationAsInteger
+type Duration struct{ timetype.Duration }
+
+// MarshalCBOR implements cbor.Marshaler and encodes the duration as a text
+// string or as the integer amount of nanoseconds
+func (d Duration) MarshalCBOR() ([]byte, error) {
+	b, err := d.Duration.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	if durationAsInteger {
+		return cbor.Marshal(int64(d.Duration))
+	}
+	return cbor.Marshal(string(b))
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler and decodes either the integer amount
+// of nanoseconds or the text string, as timetype.ParseDuration does. CBOR null
+// leaves the duration unchanged.
+func (d *Duration) UnmarshalCBOR(b []byte) error {
+	var v interface{}
+	if err := cbor.Unmarshal(b, &v); err != nil {
+		return fmt.Errorf("timetypecbor: unmarshal duration: %w", err)
+	}
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case uint64:
+		if val > math.MaxInt64 {
+			return timetype.ErrInvalidDuration
+		}
+		d.Duration = timetype.Duration(val)
+		return nil
+	case int64:
+		d.Duration = timetype.Duration(val)
+		return nil
+	case string:
+		return d.Duration.UnmarshalText([]byte(val))
+	default:
+		return timetype.ErrInvalidDuration
+	}
+}
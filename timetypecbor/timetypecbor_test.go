@@ -0,0 +1,131 @@
+package timetypecbor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Semior001/timetype"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClock_CBOR(t *testing.T) {
+	type s struct {
+		C Clock `cbor:"c"`
+	}
+
+	tbl := []timetype.Clock{
+		timetype.NewUTCClock(19, 24, 5, 0),
+		timetype.NewUTCClock(0, 0, 0, 0),
+		timetype.NewUTCClock(23, 59, 59, 999999000),
+	}
+	for i, tt := range tbl {
+		b, err := cbor.Marshal(s{C: Clock{tt}})
+		require.NoError(t, err, "case #%d", i)
+
+		var res s
+		require.NoError(t, cbor.Unmarshal(b, &res), "case #%d", i)
+		assert.Equal(t, tt, res.C.Clock, "case #%d", i)
+
+		var str map[string]string
+		require.NoError(t, cbor.Unmarshal(b, &str), "case #%d", i)
+		assert.Equal(t, tt.Format(timetype.ISO8601ClockMicro), str["c"], "case #%d", i)
+	}
+
+	b, err := cbor.Marshal(map[string]string{"c": "7:24 PM"})
+	require.NoError(t, err)
+	var res s
+	require.NoError(t, cbor.Unmarshal(b, &res))
+	assert.Equal(t, timetype.NewUTCClock(19, 24, 0, 0), res.C.Clock)
+
+	b, err = cbor.Marshal(map[string]interface{}{"c": nil})
+	require.NoError(t, err)
+	require.NoError(t, cbor.Unmarshal(b, &res))
+	assert.Equal(t, timetype.NewUTCClock(19, 24, 0, 0), res.C.Clock)
+
+	b, err = cbor.Marshal(map[string]int{"c": 5})
+	require.NoError(t, err)
+	assert.Equal(t, timetype.ErrInvalidClock, cbor.Unmarshal(b, &res))
+
+	b, err = cbor.Marshal(map[string]string{"c": "abacaba"})
+	require.NoError(t, err)
+	assert.IsType(t, &timetype.UnknownFormatError{}, cbor.Unmarshal(b, &res))
+
+	var c Clock
+	assert.Error(t, c.UnmarshalCBOR([]byte{0x62, 'o'}))
+}
+
+func TestDuration_CBOR(t *testing.T) {
+	defer SetDurationAsInteger(durationAsInteger)
+
+	type s struct {
+		D Duration `cbor:"d"`
+	}
+
+	tbl := []struct {
+		arg     timetype.Duration
+		str     string
+		integer int64
+	}{
+		{arg: 0, str: "0s", integer: 0},
+		{arg: timetype.Duration(time.Hour + 5*time.Minute + 3*time.Second), str: "1h5m3s", integer: 3903000000000},
+		{arg: timetype.Duration(-1500 * time.Millisecond), str: "-1.5s", integer: -1500000000},
+		{arg: timetype.Duration(23), str: "23ns", integer: 23},
+		{arg: timetype.Duration(-24), str: "-24ns", integer: -24},
+		{arg: timetype.Duration(1 << 40), str: "18m19.511627776s", integer: 1 << 40},
+	}
+	for i, tt := range tbl {
+		SetDurationAsInteger(false)
+		b, err := cbor.Marshal(s{D: Duration{tt.arg}})
+		require.NoError(t, err, "case #%d", i)
+		var str map[string]string
+		require.NoError(t, cbor.Unmarshal(b, &str), "case #%d", i)
+		assert.Equal(t, tt.str, str["d"], "case #%d", i)
+		var res s
+		require.NoError(t, cbor.Unmarshal(b, &res), "case #%d", i)
+		assert.Equal(t, tt.arg, res.D.Duration, "case #%d", i)
+
+		SetDurationAsInteger(true)
+		b, err = cbor.Marshal(s{D: Duration{tt.arg}})
+		require.NoError(t, err, "case #%d", i)
+		var integer map[string]int64
+		require.NoError(t, cbor.Unmarshal(b, &integer), "case #%d", i)
+		assert.Equal(t, tt.integer, integer["d"], "case #%d", i)
+		res = s{}
+		require.NoError(t, cbor.Unmarshal(b, &res), "case #%d", i)
+		assert.Equal(t, tt.arg, res.D.Duration, "case #%d", i)
+	}
+
+	var d Duration
+	b, err := cbor.Marshal("7d")
+	require.NoError(t, err)
+	require.NoError(t, cbor.Unmarshal(b, &d))
+	assert.Equal(t, timetype.Duration(168*time.Hour), d.Duration)
+
+	b, err = cbor.Marshal(true)
+	require.NoError(t, err)
+	assert.Equal(t, timetype.ErrInvalidDuration, cbor.Unmarshal(b, &d))
+
+	b, err = cbor.Marshal(uint64(1 << 63))
+	require.NoError(t, err)
+	assert.Equal(t, timetype.ErrInvalidDuration, cbor.Unmarshal(b, &d))
+
+	assert.Error(t, d.UnmarshalCBOR(nil))
+	assert.Error(t, d.UnmarshalCBOR([]byte{0x19, 0x01}))
+	assert.Error(t, d.UnmarshalCBOR([]byte{0x62, 'o'}))
+	assert.Equal(t, timetype.Duration(168*time.Hour), d.Duration)
+}
+
+func TestClock_CBORStrictPrecision(t *testing.T) {
+	defer timetype.SetStrictClockPrecision(false)
+
+	c := Clock{timetype.NewUTCClock(19, 24, 5, 123456789)}
+	_, err := cbor.Marshal(c)
+	require.NoError(t, err)
+
+	timetype.SetStrictClockPrecision(true)
+	_, err = cbor.Marshal(c)
+	assert.True(t, errors.Is(err, timetype.ErrPrecisionLoss), err)
+}
@@ -0,0 +1,81 @@
+// Package timetypemsgpack adds MessagePack marshaling of vmihailenco/msgpack to
+// timetype values. It lives apart from timetype to keep the msgpack dependency optional.
+package timetypemsgpack
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Semior001/timetype"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Clock is timetype.Clock, that is marshaled into MessagePack as a string
+// in the same format as timetype.Clock.MarshalText does
+type Clock struct{ timetype.Clock }
+
+// EncodeMsgpack implements msgpack.CustomEncoder and encodes the clock as a string
+func (h Clock) EncodeMsgpack(enc *msgpack.Encoder) error {
+	b, err := h.Clock.MarshalText()
+	if err != nil {
+		return err
+	}
+	return enc.EncodeString(string(b))
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder and parses the string in any
+// of the known layouts, as timetype.ParseClock does. MessagePack nil leaves
+// the clock unchanged.
+func (h *Clock) DecodeMsgpack(dec *msgpack.Decoder) error {
+	v, err := dec.DecodeInterfaceLoose()
+	if err != nil {
+		return fmt.Errorf("timetypemsgpack: decode clock: %w", err)
+	}
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return h.Clock.UnmarshalText([]byte(val))
+	default:
+		return timetype.ErrInvalidClock
+	}
+}
+
+// Duration is timetype.Duration, that is marshaled into MessagePack as the integer
+// amount of nanoseconds in the most compact form
+type Duration struct{ timetype.Duration }
+
+// EncodeMsgpack implements msgpack.CustomEncoder and encodes
+// the duration as the integer amount of nanoseconds
+func (d Duration) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeInt(int64(d.Duration))
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder and decodes the integer or float
+// amount of nanoseconds or the string, as timetype.ParseDuration does, similarly
+// to timetype.Duration.Scan. MessagePack nil leaves the duration unchanged.
+func (d *Duration) DecodeMsgpack(dec *msgpack.Decoder) error {
+	v, err := dec.DecodeInterfaceLoose()
+	if err != nil {
+		return fmt.Errorf("timetypemsgpack: decode duration: %w", err)
+	}
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case uint64:
+		if val > math.MaxInt64 {
+			return timetype.ErrInvalidDuration
+		}
+		d.Duration = timetype.Duration(val)
+		return nil
+	case int64:
+		d.Duration = timetype.Duration(val)
+		return nil
+	case float64: // rounded to the nearest nanosecond, as in Scan
+		return d.Duration.Scan(val)
+	case string:
+		return d.Duration.UnmarshalText([]byte(val))
+	default:
+		return timetype.ErrInvalidDuration
+	}
+}
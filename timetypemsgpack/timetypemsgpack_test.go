@@ -0,0 +1,150 @@
+package timetypemsgpack
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Semior001/timetype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestClock_Msgpack(t *testing.T) {
+	type s struct {
+		C Clock `msgpack:"c"`
+	}
+
+	tbl := []timetype.Clock{
+		timetype.NewUTCClock(19, 24, 5, 0),
+		timetype.NewUTCClock(0, 0, 0, 0),
+		timetype.NewUTCClock(23, 59, 59, 999999000),
+	}
+	for i, tt := range tbl {
+		b, err := msgpack.Marshal(s{C: Clock{tt}})
+		require.NoError(t, err, "case #%d", i)
+
+		var res s
+		require.NoError(t, msgpack.Unmarshal(b, &res), "case #%d", i)
+		assert.Equal(t, tt, res.C.Clock, "case #%d", i)
+
+		var str map[string]string
+		require.NoError(t, msgpack.Unmarshal(b, &str), "case #%d", i)
+		assert.Equal(t, tt.Format(timetype.ISO8601ClockMicro), str["c"], "case #%d", i)
+	}
+
+	b, err := msgpack.Marshal(map[string]string{"c": "7:24 PM"})
+	require.NoError(t, err)
+	var res s
+	require.NoError(t, msgpack.Unmarshal(b, &res))
+	assert.Equal(t, timetype.NewUTCClock(19, 24, 0, 0), res.C.Clock)
+
+	b, err = msgpack.Marshal(map[string]int{"c": 5})
+	require.NoError(t, err)
+	assert.Equal(t, timetype.ErrInvalidClock, msgpack.Unmarshal(b, &res))
+
+	b, err = msgpack.Marshal(map[string]string{"c": "abacaba"})
+	require.NoError(t, err)
+	assert.IsType(t, &timetype.UnknownFormatError{}, msgpack.Unmarshal(b, &res))
+
+	c := Clock{timetype.NewUTCClock(19, 24, 0, 0)}
+	require.NoError(t, c.DecodeMsgpack(msgpack.NewDecoder(bytes.NewReader([]byte{0xc0}))))
+	assert.Equal(t, timetype.NewUTCClock(19, 24, 0, 0), c.Clock)
+	assert.Error(t, c.DecodeMsgpack(msgpack.NewDecoder(bytes.NewReader([]byte{0xa2, 'o'}))))
+}
+
+func TestDuration_Msgpack(t *testing.T) {
+	type s struct {
+		D Duration `msgpack:"d"`
+	}
+
+	tbl := []struct {
+		arg timetype.Duration
+		len int
+	}{
+		{arg: 0, len: 1},
+		{arg: timetype.Duration(127), len: 1},
+		{arg: timetype.Duration(-32), len: 1},
+		{arg: timetype.Duration(-33), len: 2},
+		{arg: timetype.Duration(-300), len: 3},
+		{arg: timetype.Duration(time.Second), len: 5},
+		{arg: timetype.Duration(-time.Second), len: 5},
+		{arg: timetype.Duration(time.Hour + 5*time.Minute + 3*time.Second), len: 9},
+		{arg: timetype.Duration(-time.Hour), len: 9},
+	}
+	for i, tt := range tbl {
+		b, err := msgpack.Marshal(Duration{tt.arg})
+		require.NoError(t, err, "case #%d", i)
+		assert.Len(t, b, tt.len, "case #%d", i)
+
+		var integer int64
+		require.NoError(t, msgpack.Unmarshal(b, &integer), "case #%d", i)
+		assert.Equal(t, int64(tt.arg), integer, "case #%d", i)
+
+		b, err = msgpack.Marshal(s{D: Duration{tt.arg}})
+		require.NoError(t, err, "case #%d", i)
+		var res s
+		require.NoError(t, msgpack.Unmarshal(b, &res), "case #%d", i)
+		assert.Equal(t, tt.arg, res.D.Duration, "case #%d", i)
+	}
+}
+
+func TestDuration_DecodeMsgpack(t *testing.T) {
+	tbl := []struct {
+		arg      interface{}
+		expected timetype.Duration
+		err      error
+	}{
+		{arg: uint8(200), expected: timetype.Duration(200)},
+		{arg: uint16(60000), expected: timetype.Duration(60000)},
+		{arg: uint32(4000000000), expected: timetype.Duration(4000000000)},
+		{arg: uint64(3903000000000), expected: timetype.Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: int8(-100), expected: timetype.Duration(-100)},
+		{arg: int64(-3903000000000), expected: timetype.Duration(-(time.Hour + 5*time.Minute + 3*time.Second))},
+		{arg: float64(1.5e9), expected: timetype.Duration(1500 * time.Millisecond)},
+		{arg: float32(1024), expected: timetype.Duration(1024)},
+		{arg: "1h5m3s", expected: timetype.Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: "7d", expected: timetype.Duration(168 * time.Hour)},
+		{arg: "3903000000000", expected: timetype.Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{arg: uint64(1 << 63), err: timetype.ErrInvalidDuration},
+		{arg: true, err: timetype.ErrInvalidDuration},
+		{arg: []int{1}, err: timetype.ErrInvalidDuration},
+	}
+	for i, tt := range tbl {
+		b, err := msgpack.Marshal(tt.arg)
+		require.NoError(t, err, "case #%d", i)
+
+		var d Duration
+		err = msgpack.Unmarshal(b, &d)
+		if tt.err != nil {
+			assert.Equal(t, tt.err, err, "case #%d", i)
+			continue
+		}
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, d.Duration, "case #%d", i)
+	}
+
+	d := Duration{timetype.Duration(time.Second)}
+	dec := func(b ...byte) *msgpack.Decoder { return msgpack.NewDecoder(bytes.NewReader(b)) }
+	assert.NoError(t, d.DecodeMsgpack(dec(0xc0)))
+	assert.Equal(t, timetype.Duration(time.Second), d.Duration)
+	assert.Error(t, d.DecodeMsgpack(dec()))
+	assert.Error(t, d.DecodeMsgpack(dec(0xd2, 0, 0)))
+	assert.Error(t, d.DecodeMsgpack(dec(0xa2, 'o')))
+	assert.Error(t, d.DecodeMsgpack(dec(0xa1, 'o')))
+	assert.Equal(t, timetype.Duration(time.Second), d.Duration)
+}
+
+func TestClock_MsgpackStrictPrecision(t *testing.T) {
+	defer timetype.SetStrictClockPrecision(false)
+
+	c := Clock{timetype.NewUTCClock(19, 24, 5, 123456789)}
+	_, err := msgpack.Marshal(c)
+	require.NoError(t, err)
+
+	timetype.SetStrictClockPrecision(true)
+	_, err = msgpack.Marshal(c)
+	assert.True(t, errors.Is(err, timetype.ErrPrecisionLoss), err)
+}
@@ -0,0 +1,54 @@
+// Package timetypepb converts timetype values to and from the protobuf well-known
+// types. It lives apart from timetype to keep the protobuf dependency optional.
+package timetypepb
+
+import (
+	"time"
+
+	"github.com/Semior001/timetype"
+	"google.golang.org/genproto/googleapis/type/timeofday"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// DurationToProto returns the duration as google.protobuf.Duration
+func DurationToProto(d timetype.Duration) *durationpb.Duration {
+	return durationpb.New(time.Duration(d))
+}
+
+// DurationFromProto returns the duration of google.protobuf.Duration, nil is
+// considered to be zero. Values out of the range of time.Duration are saturated.
+func DurationFromProto(d *durationpb.Duration) timetype.Duration {
+	if d == nil {
+		return 0
+	}
+	return timetype.Duration(d.AsDuration())
+}
+
+// ClockToTimeOfDay returns the wall clock of the clock as google.type.TimeOfDay,
+// the location of the clock is lost. The end of the day is returned as 24:00:00,
+// that google.type.TimeOfDay allows for closing times.
+func ClockToTimeOfDay(c timetype.Clock) *timeofday.TimeOfDay {
+	if c.IsEndOfDay() {
+		return &timeofday.TimeOfDay{Hours: 24}
+	}
+	return &timeofday.TimeOfDay{
+		Hours:   int32(c.Hour()),
+		Minutes: int32(c.Minute()),
+		Seconds: int32(c.Second()),
+		Nanos:   int32(c.Nanosecond()),
+	}
+}
+
+// ClockFromTimeOfDay returns the UTC clock with the time of google.type.TimeOfDay,
+// nil is considered to be the zero Clock and 24:00:00 is the end of the day, see
+// timetype.EndOfDay. timetype.ErrInvalidClock is returned if any of the fields is
+// out of its range.
+func ClockFromTimeOfDay(t *timeofday.TimeOfDay) (timetype.Clock, error) {
+	if t == nil {
+		return timetype.Clock{}, nil
+	}
+	if t.Hours == 24 && t.Minutes == 0 && t.Seconds == 0 && t.Nanos == 0 {
+		return timetype.EndOfDay(time.UTC), nil
+	}
+	return timetype.NewClockChecked(int(t.Hours), int(t.Minutes), int(t.Seconds), int(t.Nanos), time.UTC)
+}
@@ -0,0 +1,92 @@
+package timetypepb
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Semior001/timetype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/type/timeofday"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestDurationToProto(t *testing.T) {
+	tbl := []struct {
+		arg      timetype.Duration
+		expected *durationpb.Duration
+	}{
+		{arg: 0, expected: &durationpb.Duration{}},
+		{arg: timetype.Duration(time.Hour + 500*time.Millisecond), expected: &durationpb.Duration{Seconds: 3600, Nanos: 500000000}},
+		{arg: timetype.Duration(-1500 * time.Millisecond), expected: &durationpb.Duration{Seconds: -1, Nanos: -500000000}},
+		{arg: timetype.Duration(math.MaxInt64), expected: &durationpb.Duration{Seconds: 9223372036, Nanos: 854775807}},
+	}
+	for i, tt := range tbl {
+		pb := DurationToProto(tt.arg)
+		assert.Equal(t, tt.expected.Seconds, pb.Seconds, "case #%d", i)
+		assert.Equal(t, tt.expected.Nanos, pb.Nanos, "case #%d", i)
+		assert.Equal(t, tt.arg, DurationFromProto(pb), "case #%d", i)
+	}
+}
+
+func TestDurationFromProto(t *testing.T) {
+	tbl := []struct {
+		arg      *durationpb.Duration
+		expected timetype.Duration
+	}{
+		{arg: nil, expected: 0},
+		{arg: &durationpb.Duration{Seconds: 3903, Nanos: 1}, expected: timetype.Duration(time.Hour + 5*time.Minute + 3*time.Second + 1)},
+		{arg: &durationpb.Duration{Seconds: math.MaxInt64}, expected: timetype.Duration(math.MaxInt64)},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.expected, DurationFromProto(tt.arg), "case #%d", i)
+	}
+}
+
+func TestClockToTimeOfDay(t *testing.T) {
+	tod := ClockToTimeOfDay(timetype.NewClock(19, 24, 5, 123456789, time.FixedZone("", 3*60*60)))
+	assert.Equal(t, int32(19), tod.Hours)
+	assert.Equal(t, int32(24), tod.Minutes)
+	assert.Equal(t, int32(5), tod.Seconds)
+	assert.Equal(t, int32(123456789), tod.Nanos)
+
+	c, err := ClockFromTimeOfDay(ClockToTimeOfDay(timetype.NewUTCClock(23, 59, 59, 999999999)))
+	require.NoError(t, err)
+	assert.Equal(t, timetype.NewUTCClock(23, 59, 59, 999999999), c)
+
+	tod = ClockToTimeOfDay(timetype.EndOfDay(time.UTC))
+	assert.Equal(t, int32(24), tod.Hours)
+	assert.Equal(t, int32(0), tod.Minutes)
+	assert.Equal(t, int32(0), tod.Seconds)
+	assert.Equal(t, int32(0), tod.Nanos)
+	c, err = ClockFromTimeOfDay(tod)
+	require.NoError(t, err)
+	assert.True(t, c.IsEndOfDay())
+}
+
+func TestClockFromTimeOfDay(t *testing.T) {
+	tbl := []struct {
+		arg      *timeofday.TimeOfDay
+		expected timetype.Clock
+		err      error
+	}{
+		{arg: nil, expected: timetype.Clock{}},
+		{arg: &timeofday.TimeOfDay{}, expected: timetype.NewUTCClock(0, 0, 0, 0)},
+		{arg: &timeofday.TimeOfDay{Hours: 7, Minutes: 30, Seconds: 15, Nanos: 5}, expected: timetype.NewUTCClock(7, 30, 15, 5)},
+		{arg: &timeofday.TimeOfDay{Hours: 24}, expected: timetype.EndOfDay(time.UTC)},
+		{arg: &timeofday.TimeOfDay{Hours: 24, Nanos: 1}, err: timetype.ErrInvalidClock},
+		{arg: &timeofday.TimeOfDay{Hours: 24, Minutes: 1}, err: timetype.ErrInvalidClock},
+		{arg: &timeofday.TimeOfDay{Hours: 25}, err: timetype.ErrInvalidClock},
+		{arg: &timeofday.TimeOfDay{Minutes: -1}, err: timetype.ErrInvalidClock},
+	}
+	for i, tt := range tbl {
+		c, err := ClockFromTimeOfDay(tt.arg)
+		if tt.err != nil {
+			assert.Equal(t, tt.err, err, "case #%d", i)
+			continue
+		}
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, c, "case #%d", i)
+	}
+}
@@ -0,0 +1,118 @@
+package timetype
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrUnknownUnit is returned if the name of the duration unit is not known
+var ErrUnknownUnit = errors.New("timetype: unknown duration unit")
+
+// Unit is a unit of durations, used by the extended parsing of durations,
+// like "7d" in ParseDuration or [5, "minutes"] in Duration.UnmarshalJSON
+type Unit int
+
+// Units of durations
+const (
+	Nanosecond Unit = iota + 1
+	Microsecond
+	Millisecond
+	Second
+	Minute
+	Hour
+	Day
+	Week
+	Month
+	Year
+)
+
+// units are names, symbols and lengths of units, indexed by Unit-1,
+// months and years have no fixed length
+var units = []struct {
+	name, symbol string
+	length       time.Duration
+}{
+	{name: "nanoseconds", symbol: "ns", length: time.Nanosecond},
+	{name: "microseconds", symbol: "us", length: time.Microsecond},
+	{name: "milliseconds", symbol: "ms", length: time.Millisecond},
+	{name: "seconds", symbol: "s", length: time.Second},
+	{name: "minutes", symbol: "m", length: time.Minute},
+	{name: "hours", symbol: "h", length: time.Hour},
+	{name: "days", symbol: "d", length: day},
+	{name: "weeks", symbol: "w", length: 7 * day},
+	{name: "months", symbol: "mo"},
+	{name: "years", symbol: "y"},
+}
+
+// ParseUnit parses the unit either by its name, like "minutes", or by its symbol,
+// as ParseDuration accepts it, like "m", "µs" or "d"
+func ParseUnit(s string) (Unit, error) {
+	if u, ok := parseUnitSymbol(s); ok {
+		return u, nil
+	}
+	for i, u := range units {
+		if u.name == s {
+			return Unit(i + 1), nil
+		}
+	}
+	return 0, ErrUnknownUnit
+}
+
+// parseUnitSymbol returns the unit with the given symbol, as ParseDuration accepts it
+func parseUnitSymbol(s string) (Unit, bool) {
+	switch s {
+	case "µs", "μs": // U+00B5 and U+03BC, as time.ParseDuration accepts
+		return Microsecond, true
+	}
+	for i, u := range units {
+		if u.symbol == s {
+			return Unit(i + 1), true
+		}
+	}
+	return 0, false
+}
+
+// valid reports whether the unit is one of the known units
+func (u Unit) valid() bool {
+	return u >= Nanosecond && u <= Year
+}
+
+// String implements fmt.Stringer and returns the name of the unit, like "minutes"
+func (u Unit) String() string {
+	if !u.valid() {
+		return "Unit(" + strconv.Itoa(int(u)) + ")"
+	}
+	return units[u-1].name
+}
+
+// Duration returns the length of the unit, considering days to be exactly 24 hours long.
+// ErrAmbiguousUnit is returned for months and years, which have no fixed length.
+func (u Unit) Duration() (time.Duration, error) {
+	switch {
+	case !u.valid():
+		return 0, ErrUnknownUnit
+	case units[u-1].length == 0:
+		return 0, ErrAmbiguousUnit
+	}
+	return units[u-1].length, nil
+}
+
+// MarshalText implements encoding.TextMarshaler and marshals the unit into its name,
+// like "minutes", so the unit is marshaled into JSON as a string
+func (u Unit) MarshalText() ([]byte, error) {
+	if !u.valid() {
+		return nil, ErrUnknownUnit
+	}
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and parses the unit, as ParseUnit does
+func (u *Unit) UnmarshalText(b []byte) error {
+	tmp, err := ParseUnit(string(b))
+	if err != nil {
+		return err
+	}
+	*u = tmp
+	return nil
+}
@@ -0,0 +1,97 @@
+package timetype
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUnit(t *testing.T) {
+	tbl := []struct {
+		unit    Unit
+		name    string
+		symbols []string
+		length  time.Duration
+	}{
+		{unit: Nanosecond, name: "nanoseconds", symbols: []string{"ns"}, length: time.Nanosecond},
+		{unit: Microsecond, name: "microseconds", symbols: []string{"us", "µs", "μs"}, length: time.Microsecond},
+		{unit: Millisecond, name: "milliseconds", symbols: []string{"ms"}, length: time.Millisecond},
+		{unit: Second, name: "seconds", symbols: []string{"s"}, length: time.Second},
+		{unit: Minute, name: "minutes", symbols: []string{"m"}, length: time.Minute},
+		{unit: Hour, name: "hours", symbols: []string{"h"}, length: time.Hour},
+		{unit: Day, name: "days", symbols: []string{"d"}, length: 24 * time.Hour},
+		{unit: Week, name: "weeks", symbols: []string{"w"}, length: 7 * 24 * time.Hour},
+	}
+	for i, tt := range tbl {
+		assert.Equal(t, tt.name, tt.unit.String(), "case #%d", i)
+		for _, s := range append([]string{tt.name}, tt.symbols...) {
+			u, err := ParseUnit(s)
+			require.NoError(t, err, "case #%d, %s", i, s)
+			assert.Equal(t, tt.unit, u, "case #%d, %s", i, s)
+		}
+		d, err := tt.unit.Duration()
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.length, d, "case #%d", i)
+
+		u, err := ParseUnit(tt.unit.String())
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.unit, u, "case #%d", i)
+	}
+
+	for i, tt := range []string{"", "minute", "Minutes", "M", "fortnights", "1m"} {
+		_, err := ParseUnit(tt)
+		assert.Equal(t, ErrUnknownUnit, err, "case #%d", i)
+	}
+}
+
+func TestUnit_Duration(t *testing.T) {
+	for i, tt := range []struct {
+		arg  string
+		unit Unit
+	}{{arg: "months", unit: Month}, {arg: "mo", unit: Month}, {arg: "years", unit: Year}, {arg: "y", unit: Year}} {
+		u, err := ParseUnit(tt.arg)
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.unit, u, "case #%d", i)
+		_, err = u.Duration()
+		assert.Equal(t, ErrAmbiguousUnit, err, "case #%d", i)
+	}
+
+	for i, u := range []Unit{0, Year + 1, -1} {
+		_, err := u.Duration()
+		assert.Equal(t, ErrUnknownUnit, err, "case #%d", i)
+	}
+	assert.Equal(t, "Unit(0)", Unit(0).String())
+	assert.Equal(t, "Unit(11)", (Year + 1).String())
+}
+
+func TestUnit_JSON(t *testing.T) {
+	type s struct {
+		Unit Unit `json:"unit"`
+	}
+	for i, u := range []Unit{Nanosecond, Minute, Week, Month, Year} {
+		b, err := json.Marshal(s{Unit: u})
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, `{"unit":"`+u.String()+`"}`, string(b), "case #%d", i)
+
+		var res s
+		require.NoError(t, json.Unmarshal(b, &res), "case #%d", i)
+		assert.Equal(t, u, res.Unit, "case #%d", i)
+	}
+
+	var res s
+	require.NoError(t, json.Unmarshal([]byte(`{"unit":"h"}`), &res))
+	assert.Equal(t, Hour, res.Unit)
+	assert.Equal(t, ErrUnknownUnit, json.Unmarshal([]byte(`{"unit":"fortnights"}`), &res))
+	assert.Equal(t, Hour, res.Unit)
+
+	_, err := json.Marshal(s{})
+	require.Error(t, err)
+
+	var d Duration
+	assert.Equal(t, ErrAmbiguousUnit, d.UnmarshalJSON([]byte(`[1, "months"]`)))
+	require.NoError(t, d.UnmarshalJSON([]byte(`[90, "s"]`)))
+	assert.Equal(t, Duration(90*time.Second), d)
+}
@@ -0,0 +1,77 @@
+package timetype
+
+import (
+	"encoding/xml"
+)
+
+// MarshalXML implements xml.Marshaler and writes the clock as the text
+// of the element in the same format as MarshalJSON does
+func (h Clock) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := h.checkPrecision(); err != nil {
+		return err
+	}
+	return wrapExternalErr("marshal", "clock", e.EncodeElement(h.Format(defaultClockLayout), start))
+}
+
+// UnmarshalXML implements xml.Unmarshaler and parses the text
+// of the element in any of the known layouts
+func (h *Clock) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var val string
+	if err := d.DecodeElement(&val, &start); err != nil {
+		return wrapExternalErr("unmarshal", "clock", err)
+	}
+	c, err := ParseClock(val)
+	if err != nil {
+		return err
+	}
+	*h = c
+	return nil
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr and writes the clock
+// as the attribute value in the same format as MarshalJSON does
+func (h Clock) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if err := h.checkPrecision(); err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: h.Format(defaultClockLayout)}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr and parses
+// the attribute value in any of the known layouts
+func (h *Clock) UnmarshalXMLAttr(attr xml.Attr) error {
+	c, err := ParseClock(attr.Value)
+	if err != nil {
+		return err
+	}
+	*h = c
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler and writes the duration as the text
+// of the element in the same format as MarshalJSON does
+func (d Duration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return wrapExternalErr("marshal", "duration", e.EncodeElement(d.String(), start))
+}
+
+// UnmarshalXML implements xml.Unmarshaler and parses the text of the element either
+// as the duration string, like "1h5m3s", or as the integer amount of nanoseconds
+func (d *Duration) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var val string
+	if err := dec.DecodeElement(&val, &start); err != nil {
+		return wrapExternalErr("unmarshal", "duration", err)
+	}
+	return d.UnmarshalText([]byte(val))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr and writes the duration
+// as the attribute value in the same format as MarshalJSON does
+func (d Duration) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: d.String()}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr and parses the attribute value either
+// as the duration string, like "1h5m3s", or as the integer amount of nanoseconds
+func (d *Duration) UnmarshalXMLAttr(attr xml.Attr) error {
+	return d.UnmarshalText([]byte(attr.Value))
+}
@@ -0,0 +1,93 @@
+package timetype
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type xmlSchedule struct {
+	XMLName xml.Name `xml:"schedule"`
+	Opens   Clock    `xml:"opens,attr"`
+	Closes  Clock    `xml:"closes"`
+}
+
+func TestClock_MarshalXML(t *testing.T) {
+	b, err := xml.Marshal(xmlSchedule{Opens: NewUTCClock(9, 0, 0, 0), Closes: NewUTCClock(19, 24, 0, 0)})
+	require.NoError(t, err)
+	assert.Equal(t, `<schedule opens="09:00:00.000000"><closes>19:24:00.000000</closes></schedule>`, string(b))
+}
+
+func TestClock_UnmarshalXML(t *testing.T) {
+	var s xmlSchedule
+	err := xml.Unmarshal([]byte(`<schedule opens="09:00:00"><closes>7:24 PM</closes></schedule>`), &s)
+	require.NoError(t, err)
+	assert.Equal(t, NewUTCClock(9, 0, 0, 0), s.Opens)
+	assert.Equal(t, NewUTCClock(19, 24, 0, 0), s.Closes)
+
+	// errors
+	err = xml.Unmarshal([]byte(`<schedule opens="abacaba"><closes>19:24:00</closes></schedule>`), &s)
+	require.Error(t, err)
+	assert.IsType(t, &UnknownFormatError{}, err, "invalid attribute")
+
+	err = xml.Unmarshal([]byte(`<schedule opens="09:00:00"><closes>abacaba</closes></schedule>`), &s)
+	require.Error(t, err)
+	assert.IsType(t, &UnknownFormatError{}, err, "invalid element")
+
+	err = xml.Unmarshal([]byte(`<schedule opens="09:00:00"><closes>19:24:00</schedule>`), &s)
+	require.Error(t, err)
+	assert.IsType(t, &errExternal{}, err, "malformed element")
+}
+
+type xmlRequest struct {
+	XMLName xml.Name `xml:"request"`
+	Timeout Duration `xml:"timeout,attr"`
+	Retry   Duration `xml:"retry"`
+}
+
+func TestDuration_MarshalXML(t *testing.T) {
+	b, err := xml.Marshal(xmlRequest{Timeout: Duration(time.Hour + 5*time.Minute + 3*time.Second), Retry: Duration(time.Second)})
+	require.NoError(t, err)
+	assert.Equal(t, `<request timeout="1h5m3s"><retry>1s</retry></request>`, string(b))
+}
+
+func TestDuration_UnmarshalXML(t *testing.T) {
+	tbl := []struct {
+		doc     string
+		timeout Duration
+		retry   Duration
+		err     error
+	}{
+		{
+			doc:     `<request timeout="1h5m3s"><retry>1s</retry></request>`,
+			timeout: Duration(time.Hour + 5*time.Minute + 3*time.Second),
+			retry:   Duration(time.Second),
+		},
+		{
+			doc:     `<request timeout="3903000000000"><retry>1000000000</retry></request>`,
+			timeout: Duration(time.Hour + 5*time.Minute + 3*time.Second),
+			retry:   Duration(time.Second),
+		},
+		{doc: `<request timeout=""><retry>1s</retry></request>`, err: ErrInvalidDuration},
+		{doc: `<request timeout="1s"><retry></retry></request>`, err: ErrInvalidDuration},
+	}
+	for i, tt := range tbl {
+		var r xmlRequest
+		err := xml.Unmarshal([]byte(tt.doc), &r)
+		if tt.err != nil {
+			assert.Equal(t, tt.err, err, "case #%d", i)
+			continue
+		}
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.timeout, r.Timeout, "case #%d", i)
+		assert.Equal(t, tt.retry, r.Retry, "case #%d", i)
+	}
+
+	var r xmlRequest
+	err := xml.Unmarshal([]byte(`<request timeout="1s"><retry>1hour</retry></request>`), &r)
+	require.Error(t, err)
+	assert.IsType(t, &errExternal{}, err, "unknown unit")
+}
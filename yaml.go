@@ -0,0 +1,61 @@
+package timetype
+
+// MarshalYAML implements yaml.Marshaler and marshals
+// the clock in the same format as MarshalJSON does
+func (h Clock) MarshalYAML() (interface{}, error) {
+	if err := h.checkPrecision(); err != nil {
+		return nil, err
+	}
+	return h.Format(defaultClockLayout), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler and parses
+// the clock in any of the known layouts
+func (h *Clock) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var val string
+	if err := unmarshal(&val); err != nil {
+		return wrapExternalErr("unmarshal", "clock", err)
+	}
+	c, err := ParseClock(val)
+	if err != nil {
+		return err
+	}
+	*h = c
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler and marshals
+// the duration in the same format as MarshalJSON does
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler and parses either the duration
+// string, like "1h5m3s", or the integer amount of nanoseconds
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return wrapExternalErr("unmarshal", "duration", err)
+	}
+	switch value := v.(type) {
+	case int:
+		*d = Duration(value)
+		return nil
+	case float64:
+		tmp, err := durationFromFloat(value)
+		if err != nil {
+			return err
+		}
+		*d = tmp
+		return nil
+	case string:
+		tmp, err := parseDurationString(value)
+		if err != nil {
+			return withErrOp("unmarshal", err)
+		}
+		*d = tmp
+		return nil
+	default:
+		return ErrInvalidDuration
+	}
+}
@@ -0,0 +1,75 @@
+package timetype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestClock_MarshalYAML(t *testing.T) {
+	b, err := yaml.Marshal(struct {
+		Opens  Clock `yaml:"opens"`
+		Closes Clock `yaml:"closes"`
+	}{Opens: NewUTCClock(9, 0, 0, 0), Closes: NewUTCClock(19, 24, 0, 0)})
+	require.NoError(t, err)
+	assert.Equal(t, "opens: \"09:00:00.000000\"\ncloses: \"19:24:00.000000\"\n", string(b))
+}
+
+func TestClock_UnmarshalYAML(t *testing.T) {
+	var cfg struct {
+		Opens  Clock `yaml:"opens"`
+		Closes Clock `yaml:"closes"`
+	}
+	err := yaml.Unmarshal([]byte("opens: 09:00:00\ncloses: \"7:24 PM\"\n"), &cfg)
+	require.NoError(t, err)
+	assert.Equal(t, NewUTCClock(9, 0, 0, 0), cfg.Opens)
+	assert.Equal(t, NewUTCClock(19, 24, 0, 0), cfg.Closes)
+
+	// errors
+	err = yaml.Unmarshal([]byte("opens: abacaba\n"), &cfg)
+	require.Error(t, err)
+	assert.IsType(t, &UnknownFormatError{}, err)
+
+	err = yaml.Unmarshal([]byte("opens: [09:00:00]\n"), &cfg)
+	require.Error(t, err)
+	assert.IsType(t, &errExternal{}, err, "clock should be a scalar")
+}
+
+func TestDuration_MarshalYAML(t *testing.T) {
+	b, err := yaml.Marshal(struct {
+		Timeout Duration `yaml:"timeout"`
+	}{Timeout: Duration(time.Hour + 5*time.Minute + 3*time.Second)})
+	require.NoError(t, err)
+	assert.Equal(t, "timeout: 1h5m3s\n", string(b))
+}
+
+func TestDuration_UnmarshalYAML(t *testing.T) {
+	tbl := []struct {
+		doc      string
+		expected Duration
+		err      string
+	}{
+		{doc: "timeout: 1h5m3s", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{doc: "timeout: \"1h5m3s\"", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{doc: "timeout: 3903000000000", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{doc: "timeout: PT1H5M3S", expected: Duration(time.Hour + 5*time.Minute + 3*time.Second)},
+		{doc: "timeout: true", err: "timetype: invalid duration"},
+		{doc: "timeout: [1h]", err: "timetype: invalid duration"},
+		{doc: "timeout: 1hour", err: "timetype: unmarshal duration: time: unknown unit \"hour\" in duration \"1hour\""},
+	}
+	for i, tt := range tbl {
+		var cfg struct {
+			Timeout Duration `yaml:"timeout"`
+		}
+		err := yaml.Unmarshal([]byte(tt.doc), &cfg)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, "case #%d", i)
+			continue
+		}
+		require.NoError(t, err, "case #%d", i)
+		assert.Equal(t, tt.expected, cfg.Timeout, "case #%d", i)
+	}
+}